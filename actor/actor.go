@@ -0,0 +1,178 @@
+// Package actor provides Game, a goroutine safe wrapper around a GameState.
+//
+// A Game owns a GameState and accesses it from a single goroutine only.
+// Other goroutines send requests to this goroutine, which are executed one after another.
+// Events that happen in the game are sent to any number of subscribers.
+package actor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Game owns a GameState and serializes all access to it.
+// All methods of Game are goroutine safe.
+type Game struct {
+	requests chan request
+
+	close     chan struct{} // closed when Close is called
+	closeOnce sync.Once
+	stopped   chan struct{} // closed once the game goroutine has stopped
+
+	// owned by the game goroutine
+	state       *model.GameState
+	published   int
+	subscribers map[*Subscription]struct{}
+}
+
+// request is a function to be executed by the game goroutine
+type request struct {
+	ctx   context.Context
+	f     func() error
+	reply chan error
+}
+
+// ErrClosed is returned when making a request to a Game that has been closed.
+var ErrClosed = errors.New("actor: Game has been closed")
+
+// New creates a new Game that owns state.
+// After calling New, state must no longer be accessed by the caller.
+//
+// New starts a goroutine that runs until Close is called.
+func New(state *model.GameState) *Game {
+	game := &Game{
+		requests: make(chan request),
+		close:    make(chan struct{}),
+		stopped:  make(chan struct{}),
+
+		state:       state,
+		published:   len(state.History),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+	go game.run()
+	return game
+}
+
+// run executes requests until the game is closed.
+func (game *Game) run() {
+	defer close(game.stopped)
+
+	for {
+		select {
+		case r := <-game.requests:
+			if err := r.ctx.Err(); err != nil {
+				r.reply <- err
+				continue
+			}
+			r.reply <- r.f()
+			game.publish()
+		case <-game.close:
+			for s := range game.subscribers {
+				s.end()
+			}
+			return
+		}
+	}
+}
+
+// Close stops the game.
+// Afterwards, all requests return ErrClosed and the channels of all subscriptions are closed once they have been drained.
+func (game *Game) Close() {
+	game.closeOnce.Do(func() { close(game.close) })
+	<-game.stopped
+}
+
+// do executes f on the game goroutine.
+//
+// When ctx is done before f is executed, f is not executed and the error of ctx is returned.
+// Once f has started to execute, do waits for it to finish and returns its error.
+func (game *Game) do(ctx context.Context, f func() error) error {
+	r := request{ctx: ctx, f: f, reply: make(chan error, 1)}
+	select {
+	case game.requests <- r:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-game.stopped:
+		return ErrClosed
+	}
+	return <-r.reply
+}
+
+// Do calls f with the GameState owned by this game, see do for the semantics of ctx.
+// The state may only be accessed until f returns.
+// Any events that happened during f are sent to subscribers afterwards.
+func (game *Game) Do(ctx context.Context, f func(state *model.GameState) error) error {
+	return game.do(ctx, func() error { return f(game.state) })
+}
+
+// AddPlayer adds a new player to the game and returns their id, see GameState.AddPlayer.
+func (game *Game) AddPlayer(ctx context.Context) (id uuid.UUID, err error) {
+	err = game.Do(ctx, func(state *model.GameState) error {
+		player, err := state.AddPlayer()
+		if err != nil {
+			return err
+		}
+		id = player.ID
+		return nil
+	})
+	return id, err
+}
+
+// RemovePlayer removes the player with the provided id from the game, see GameState.RemovePlayer.
+func (game *Game) RemovePlayer(ctx context.Context, id uuid.UUID) error {
+	return game.Do(ctx, func(state *model.GameState) error {
+		return state.RemovePlayer(id)
+	})
+}
+
+// Start starts the game, see GameState.Start.
+func (game *Game) Start(ctx context.Context, seed int64) error {
+	return game.Do(ctx, func(state *model.GameState) error {
+		return state.Start(seed)
+	})
+}
+
+// Apply applies move to the game, see GameState.Apply.
+func (game *Game) Apply(ctx context.Context, move model.Move) error {
+	return game.Do(ctx, func(state *model.GameState) error {
+		return state.Apply(move)
+	})
+}
+
+// View returns the game as seen by the player with the provided id, see GameState.PlayerView.
+func (game *Game) View(ctx context.Context, id uuid.UUID) (view *model.View, err error) {
+	err = game.Do(ctx, func(state *model.GameState) (err error) {
+		view, err = state.PlayerView(id)
+		return err
+	})
+	return view, err
+}
+
+// State returns a copy of the GameState owned by this game.
+func (game *Game) State(ctx context.Context) (clone *model.GameState, err error) {
+	err = game.Do(ctx, func(state *model.GameState) error {
+		clone = state.Clone()
+		return nil
+	})
+	return clone, err
+}
+
+// publish sends the events that have not yet been published to all subscribers.
+// When the history has been shortened, for instance by GameState.Rewind, only new events are published.
+func (game *Game) publish() {
+	history := game.state.History
+	if game.published > len(history) {
+		game.published = len(history)
+	}
+
+	for _, event := range history[game.published:] {
+		for s := range game.subscribers {
+			s.push(event)
+		}
+	}
+	game.published = len(history)
+}
@@ -0,0 +1,183 @@
+package actor
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// newTestGame creates a new Game with the provided number of players that has been started.
+func newTestGame(t *testing.T, players int) (*Game, []uuid.UUID) {
+	game := New(&model.GameState{Mode: model.ModeFiveColor})
+
+	ctx := context.Background()
+	ids := make([]uuid.UUID, players)
+	for i := range ids {
+		var err error
+		if ids[i], err = game.AddPlayer(ctx); err != nil {
+			t.Fatalf("Game.AddPlayer() error = %v", err)
+		}
+	}
+	if err := game.Start(ctx, 1); err != nil {
+		t.Fatalf("Game.Start() error = %v", err)
+	}
+	return game, ids
+}
+
+// collect receives all events from s until its channel is closed.
+func collect(s *Subscription) (events []model.Event) {
+	for event := range s.Events() {
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestGame_Apply(t *testing.T) {
+	game, ids := newTestGame(t, 3)
+	ctx := context.Background()
+
+	// subscribe before and after some moves have been made
+	all, err := game.Subscribe(ctx, uuid.Nil)
+	if err != nil {
+		t.Fatalf("Game.Subscribe() error = %v", err)
+	}
+
+	// every player tries to make a move concurrently, until the game is over.
+	// only the current player succeeds.
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			for {
+				view, err := game.View(ctx, id)
+				if err != nil {
+					t.Errorf("Game.View() error = %v", err)
+					return
+				}
+				if view.Outcome != model.OutcomeNone {
+					return
+				}
+				if moves := view.LegalMoves(); moves != nil {
+					err := game.Apply(ctx, moves[0])
+					if err != nil && err != model.ErrNotYourTurn && err != model.ErrGameOver {
+						t.Errorf("Game.Apply() error = %v", err)
+						return
+					}
+				}
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	late, err := game.Subscribe(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("Game.Subscribe() error = %v", err)
+	}
+
+	state, err := game.State(ctx)
+	if err != nil {
+		t.Fatalf("Game.State() error = %v", err)
+	}
+	if !state.Over() {
+		t.Error("game is not over")
+	}
+
+	game.Close()
+
+	if got := collect(all); !reflect.DeepEqual(got, state.History) {
+		t.Error("subscription did not receive history")
+	}
+
+	want, err := state.PlayerHistory(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := collect(late); !reflect.DeepEqual(got, want) {
+		t.Error("late subscription did not receive redacted history")
+	}
+}
+
+func TestGame_Subscribe_Slow(t *testing.T) {
+	game, _ := newTestGame(t, 2)
+	ctx := context.Background()
+
+	// a subscription that never receives must not block the game
+	slow, err := game.Subscribe(ctx, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast, err := game.Subscribe(ctx, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range fast.Events() {
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		err := game.Do(ctx, func(state *model.GameState) error {
+			return state.Apply(state.LegalMoves()[len(state.LegalMoves())-1])
+		})
+		if err != nil {
+			t.Fatalf("Game.Do() error = %v", err)
+		}
+	}
+
+	slow.Close()
+	if _, ok := <-slow.Events(); ok {
+		t.Error("closed subscription still delivers events")
+	}
+
+	game.Close()
+	<-done
+
+	if _, err := game.Subscribe(ctx, uuid.Nil); err != ErrClosed {
+		t.Errorf("Game.Subscribe() error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestGame_Context(t *testing.T) {
+	game, ids := newTestGame(t, 2)
+	defer game.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := game.Do(ctx, func(state *model.GameState) error {
+		called = true
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Game.Do() error = %v, want %v", err, context.Canceled)
+	}
+	if called {
+		t.Error("Game.Do() executed request with cancelled context")
+	}
+
+	if _, err := game.Subscribe(context.Background(), uuid.New()); err != model.ErrUnknownPlayer {
+		t.Errorf("Game.Subscribe() error = %v, want %v", err, model.ErrUnknownPlayer)
+	}
+	if _, err := game.View(context.Background(), ids[1]); err != nil {
+		t.Errorf("Game.View() error = %v", err)
+	}
+}
+
+func TestGame_Close(t *testing.T) {
+	game, _ := newTestGame(t, 2)
+	game.Close()
+	game.Close()
+
+	if err := game.Apply(context.Background(), model.Move{Kind: model.MoveDiscard}); err != ErrClosed {
+		t.Errorf("Game.Apply() error = %v, want %v", err, ErrClosed)
+	}
+}
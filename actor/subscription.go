@@ -0,0 +1,154 @@
+package actor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Subscription receives the events of a Game.
+//
+// Events are queued for every subscription independently.
+// A subscription that does not receive its events does not block the game or any other subscription.
+type Subscription struct {
+	game   *Game
+	viewer uuid.UUID
+
+	events chan model.Event
+
+	m      sync.Mutex
+	queue  []model.Event
+	ended  bool
+	notify chan struct{}
+
+	quit      chan struct{}
+	closeOnce sync.Once
+	stopped   chan struct{} // closed once pump has returned
+}
+
+// Subscribe creates a new subscription to the events of this game.
+// The subscription first receives all events that have happened so far, followed by all future events.
+//
+// Events are redacted for viewer, see Event.Redact.
+// To receive events without redaction, viewer should be uuid.Nil.
+// When viewer is not part of the game, returns model.ErrUnknownPlayer.
+func (game *Game) Subscribe(ctx context.Context, viewer uuid.UUID) (*Subscription, error) {
+	s := &Subscription{
+		game:   game,
+		viewer: viewer,
+
+		events: make(chan model.Event),
+		notify: make(chan struct{}, 1),
+		quit:   make(chan struct{}),
+
+		stopped: make(chan struct{}),
+	}
+
+	err := game.do(ctx, func() error {
+		if viewer != uuid.Nil {
+			if _, err := game.state.PlayerView(viewer); err != nil {
+				return err
+			}
+		}
+
+		for _, event := range game.state.History[:game.published] {
+			s.push(event)
+		}
+		game.subscribers[s] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.pump()
+	return s, nil
+}
+
+// Events returns the channel events are sent on.
+// It is closed once the game or the subscription have been closed.
+func (s *Subscription) Events() <-chan model.Event {
+	return s.events
+}
+
+// Close closes this subscription.
+// Events that have not yet been received are dropped, once Close returns no more events are sent.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.quit)
+		s.game.do(context.Background(), func() error {
+			delete(s.game.subscribers, s)
+			return nil
+		})
+	})
+	<-s.stopped
+}
+
+// push queues event to be sent to this subscription.
+// It never blocks.
+func (s *Subscription) push(event model.Event) {
+	if s.viewer != uuid.Nil {
+		event = event.Redact(s.viewer)
+	}
+
+	s.m.Lock()
+	s.queue = append(s.queue, event)
+	s.m.Unlock()
+
+	s.wake()
+}
+
+// end indicates that no more events will be pushed.
+// The events channel is closed once all queued events have been sent.
+func (s *Subscription) end() {
+	s.m.Lock()
+	s.ended = true
+	s.m.Unlock()
+
+	s.wake()
+}
+
+// wake wakes up pump, if it is not already awake.
+func (s *Subscription) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pump sends queued events to the events channel, until the subscription is closed.
+func (s *Subscription) pump() {
+	defer close(s.stopped)
+	defer close(s.events)
+
+	for {
+		select {
+		case <-s.notify:
+		case <-s.quit:
+			return
+		}
+
+		for {
+			s.m.Lock()
+			if len(s.queue) == 0 {
+				ended := s.ended
+				s.m.Unlock()
+				if ended {
+					return
+				}
+				break
+			}
+			event := s.queue[0]
+			s.queue = s.queue[1:]
+			s.m.Unlock()
+
+			select {
+			case s.events <- event:
+			case <-s.quit:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package agent contains the interface implemented by programs that play Hanabi and a Runner that plays games with them.
+//
+// Agents never see the all-knowing GameState.
+// Instead they are given a View of the game and are notified of every Event, both redacted for the player they are seated as.
+package agent
+
+import (
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Agent represents a program that plays Hanabi as a single player.
+type Agent interface {
+	// Observe is called for every event that happens in the game, in order.
+	// Events are redacted for the player the agent is seated as.
+	Observe(event model.Event)
+
+	// Move is called when it is the turn of the player the agent is seated as.
+	// The view is redacted for this player, its Viewer field is the id of the player.
+	// The returned move is applied to the game, the ID of the move may be left empty.
+	Move(view *model.View) model.Move
+}
+
+// Rejecter is an Agent that wants to be notified when one of its moves has been rejected.
+type Rejecter interface {
+	Agent
+
+	// Reject is called when move was rejected by the game because of err.
+	Reject(move model.Move, err error)
+}
+
+// Watcher is an Agent that wants to see the game after every move.
+type Watcher interface {
+	Agent
+
+	// Watch is called once the game has started and after every move, once all events caused by it have been observed.
+	// The view is redacted for the player the agent is seated as.
+	Watch(view *model.View)
+}
+
+// Cheater is an Agent that sees the all-knowing GameState instead of a View.
+// Cheaters are not fair players, and are used to compute upper bounds for the scores of other agents.
+type Cheater interface {
+	Agent
+
+	// Cheat is called instead of Move when it is the turn of the player the agent is seated as.
+	// The state is a copy of the game, modifying it has no effect on the game.
+	Cheat(state *model.GameState) model.Move
+}
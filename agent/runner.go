@@ -0,0 +1,174 @@
+package agent
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// IllegalMovePolicy determines what a Runner does when an Agent makes an illegal move.
+type IllegalMovePolicy int
+
+// The different IllegalMovePolicies
+const (
+	// PolicyAbort aborts the game and returns an error
+	PolicyAbort IllegalMovePolicy = iota
+
+	// PolicyRetry asks the agent for another move, up to Runner.MaxRetries times.
+	// When the agent does not make a legal move, the game is aborted.
+	PolicyRetry
+
+	// PolicyFallback replaces the move with a legal move.
+	// The fallback move is discarding the oldest card, or, when this is not possible, the first legal hint.
+	PolicyFallback
+)
+
+// Runner plays games of Hanabi with Agents.
+type Runner struct {
+	// Mode is the GameMode games are played in
+	Mode model.GameMode
+
+	// Options are the rules games are played with.
+	// When nil, uses model.DefaultRuleOptions.
+	Options *model.RuleOptions
+
+	// Policy determines what happens when an agent makes an illegal move
+	Policy IllegalMovePolicy
+
+	// MaxRetries is the number of times an agent may retry a move when Policy is PolicyRetry.
+	MaxRetries int
+}
+
+// Result is the result of a game played by a Runner.
+type Result struct {
+	// Score and Outcome are the final score and outcome of the game
+	Score   int
+	Outcome model.Outcome
+
+	// History is the (all-knowing) history of the game
+	History []model.Event
+
+	// Illegal is the number of illegal moves that were rejected
+	Illegal int
+
+	// State is the final state of the game
+	State *model.GameState
+}
+
+// Run plays a single game with the provided agents, and returns the result.
+//
+// Each agent is seated as a new player, in order, and the game is started using seed, see GameState.Start.
+// Agents are then asked for moves until the game is over.
+// When an agent makes an illegal move that is not handled by the Policy, returns an error with the model error as its cause.
+func (r *Runner) Run(seed int64, agents ...Agent) (*Result, error) {
+	state := &model.GameState{Mode: r.Mode}
+	if r.Options != nil {
+		state.Options = r.Options.Clone()
+	}
+
+	for range agents {
+		if _, err := state.AddPlayer(); err != nil {
+			return nil, errors.Wrap(err, "Runner: Unable to seat agent")
+		}
+	}
+	if err := state.Start(seed); err != nil {
+		return nil, errors.Wrap(err, "Runner: Unable to start game")
+	}
+
+	result := &Result{State: state}
+
+	seen := r.notify(state, agents, 0)
+	for !state.Over() {
+		illegal, err := r.turn(state, agents[state.CurrentPlayer])
+		result.Illegal += illegal
+		if err != nil {
+			return nil, errors.Wrapf(err, "Runner: Illegal move in turn %d", state.Turn)
+		}
+		seen = r.notify(state, agents, seen)
+	}
+
+	result.Score = state.Score()
+	result.Outcome = state.Outcome
+	result.History = state.History
+	return result, nil
+}
+
+// turn asks agent for a move and applies it to state.
+// It returns the number of illegal moves made by the agent.
+func (r *Runner) turn(state *model.GameState, agent Agent) (illegal int, err error) {
+	player := state.Players[state.CurrentPlayer]
+
+	for {
+		move, err := ask(state, player.ID, agent)
+		if err != nil {
+			return illegal, err
+		}
+
+		err = state.Apply(move)
+		if err == nil {
+			return illegal, nil
+		}
+
+		illegal++
+		if rejecter, ok := agent.(Rejecter); ok {
+			rejecter.Reject(move, err)
+		}
+
+		switch {
+		case r.Policy == PolicyRetry && illegal <= r.MaxRetries:
+			continue
+		case r.Policy == PolicyFallback:
+			return illegal, state.Apply(fallback(state))
+		}
+		return illegal, err
+	}
+}
+
+// ask asks agent for the move of the player with the provided id.
+func ask(state *model.GameState, id uuid.UUID, agent Agent) (model.Move, error) {
+	if cheater, ok := agent.(Cheater); ok {
+		return cheater.Cheat(state.Clone()), nil
+	}
+
+	view, err := state.PlayerView(id)
+	if err != nil {
+		return model.Move{}, err
+	}
+	return agent.Move(view), nil
+}
+
+// fallback returns the move made by PolicyFallback.
+func fallback(state *model.GameState) model.Move {
+	moves := state.LegalMoves()
+	for _, kind := range []model.MoveKind{model.MoveDiscard, model.MoveHint} {
+		for _, move := range moves {
+			if move.Kind == kind {
+				return move
+			}
+		}
+	}
+	return moves[0]
+}
+
+// notify notifies each agent of the events in the history of state, starting at index seen.
+// Afterwards, Watchers are shown the game.
+// It returns the new number of seen events.
+func (r *Runner) notify(state *model.GameState, agents []Agent, seen int) int {
+	for _, event := range state.History[seen:] {
+		for i, agent := range agents {
+			agent.Observe(event.Redact(state.Players[i].ID))
+		}
+	}
+	for i, agent := range agents {
+		watcher, ok := agent.(Watcher)
+		if !ok {
+			continue
+		}
+		view, err := state.PlayerView(state.Players[i].ID)
+		if err != nil {
+			panic("Runner.notify(): seated player not part of game")
+		}
+		watcher.Watch(view)
+	}
+	return len(state.History)
+}
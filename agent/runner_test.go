@@ -0,0 +1,198 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// testAgent discards the oldest card, or hints the next player about their oldest card when no discard is possible.
+// The first Illegal moves it makes are illegal.
+type testAgent struct {
+	Illegal int
+
+	Observed []model.Event
+	Rejected []error
+}
+
+func (a *testAgent) Observe(event model.Event) {
+	a.Observed = append(a.Observed, event)
+}
+
+func (a *testAgent) Move(view *model.View) model.Move {
+	if a.Illegal > 0 {
+		a.Illegal--
+		return model.Move{Kind: model.MovePlay, Index: -1}
+	}
+
+	if view.Hints < view.Options.MaxHints {
+		return model.Move{Kind: model.MoveDiscard, Index: 0}
+	}
+
+	next := view.Players[(view.Me()+1)%len(view.Players)]
+	return model.Move{Kind: model.MoveHint, Hint: next.Hand[0].Number.Hint(), ToPlayerID: next.ID}
+}
+
+func (a *testAgent) Reject(move model.Move, err error) {
+	a.Rejected = append(a.Rejected, err)
+}
+
+func TestRunner_Run(t *testing.T) {
+	agents := []*testAgent{{}, {}, {}}
+
+	runner := &Runner{Mode: model.ModeFiveColor}
+	result, err := runner.Run(1, agents[0], agents[1], agents[2])
+	if err != nil {
+		t.Fatalf("Runner.Run() error = %v", err)
+	}
+
+	if result.Outcome != model.OutcomeDeckout {
+		t.Errorf("Outcome = %v, want %v", result.Outcome, model.OutcomeDeckout)
+	}
+	if result.Score != result.State.Score() {
+		t.Errorf("Score = %v, want %v", result.Score, result.State.Score())
+	}
+	if result.Illegal != 0 {
+		t.Errorf("Illegal = %v, want 0", result.Illegal)
+	}
+	if result.State.Seed != 1 {
+		t.Errorf("Seed = %v, want 1", result.State.Seed)
+	}
+
+	for i, a := range agents {
+		want, err := result.State.PlayerHistory(result.State.Players[i].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(a.Observed, want) {
+			t.Errorf("agent %d did not observe redacted history", i)
+		}
+	}
+}
+
+func TestRunner_Run_Policy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      IllegalMovePolicy
+		maxRetries  int
+		illegal     int
+		wantErr     error
+		wantIllegal int
+	}{
+		{"abort", PolicyAbort, 0, 1, model.ErrInvalidIndex, 0},
+		{"retry", PolicyRetry, 2, 2, nil, 2},
+		{"retry too often", PolicyRetry, 1, 2, model.ErrInvalidIndex, 0},
+		{"fallback", PolicyFallback, 0, 3, nil, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := &testAgent{Illegal: tt.illegal}
+
+			runner := &Runner{Mode: model.ModeFiveColor, Policy: tt.policy, MaxRetries: tt.maxRetries}
+			result, err := runner.Run(1, bad, &testAgent{})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("Runner.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(bad.Rejected) != tt.illegal {
+				t.Errorf("len(Rejected) = %v, want %v", len(bad.Rejected), tt.illegal)
+			}
+			if err != nil {
+				return
+			}
+			if result.Illegal != tt.wantIllegal {
+				t.Errorf("Illegal = %v, want %v", result.Illegal, tt.wantIllegal)
+			}
+			if !result.State.Over() {
+				t.Error("Runner.Run() returned unfinished game")
+			}
+		})
+	}
+}
+
+func TestRunner_Run_Options(t *testing.T) {
+	options := model.DefaultRuleOptions()
+	options.MaxHints = 2
+
+	runner := &Runner{Mode: model.ModeFiveColor, Options: options}
+	result, err := runner.Run(1, &testAgent{}, &testAgent{})
+	if err != nil {
+		t.Fatalf("Runner.Run() error = %v", err)
+	}
+	if result.State.Options.MaxHints != 2 {
+		t.Errorf("Options.MaxHints = %v, want 2", result.State.Options.MaxHints)
+	}
+	if result.State.Options == options {
+		t.Error("Runner.Run() did not copy Options")
+	}
+
+	if _, err := runner.Run(1, &testAgent{}); errors.Cause(err) != model.ErrInvalidPlayerCount {
+		t.Errorf("Runner.Run() error = %v, want %v", err, model.ErrInvalidPlayerCount)
+	}
+}
+
+// watchingAgent is a testAgent that records the views it was shown
+type watchingAgent struct {
+	testAgent
+	Views []*model.View
+}
+
+func (a *watchingAgent) Watch(view *model.View) {
+	a.Views = append(a.Views, view)
+}
+
+// cheatingAgent is a testAgent that cheats
+type cheatingAgent struct {
+	testAgent
+	Cheated int
+}
+
+func (a *cheatingAgent) Cheat(state *model.GameState) model.Move {
+	a.Cheated++
+
+	// make sure that modifying the state has no effect
+	player := state.Players[state.CurrentPlayer]
+	player.Hand = nil
+
+	view, err := state.PlayerView(player.ID)
+	if err != nil {
+		panic(err)
+	}
+	view.Players[state.CurrentPlayer].HandSize = 1
+	return a.Move(view)
+}
+
+func (a *cheatingAgent) Move(view *model.View) model.Move {
+	if a.Cheated == 0 {
+		panic("cheatingAgent.Move() called by Runner")
+	}
+	return a.testAgent.Move(view)
+}
+
+func TestRunner_Run_Optional(t *testing.T) {
+	watcher := &watchingAgent{}
+	cheater := &cheatingAgent{}
+
+	runner := &Runner{Mode: model.ModeFiveColor}
+	result, err := runner.Run(1, watcher, cheater)
+	if err != nil {
+		t.Fatalf("Runner.Run() error = %v", err)
+	}
+
+	if len(watcher.Views) != len(result.State.Moves)+1 {
+		t.Errorf("len(Views) = %v, want %v", len(watcher.Views), len(result.State.Moves)+1)
+	}
+	for _, view := range watcher.Views {
+		if view.Viewer != result.State.Players[0].ID {
+			t.Fatalf("Watcher shown View for %v", view.Viewer)
+		}
+	}
+	if last := watcher.Views[len(watcher.Views)-1]; last.Outcome != result.Outcome {
+		t.Errorf("last View has Outcome %v, want %v", last.Outcome, result.Outcome)
+	}
+
+	if want := len(result.State.Moves) / 2; cheater.Cheated != want {
+		t.Errorf("Cheated = %v, want %v", cheater.Cheated, want)
+	}
+}
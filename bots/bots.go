@@ -0,0 +1,30 @@
+// Package bots contains reference implementations of agent.Agent.
+//
+// The bots in this package are intended as baselines to benchmark other agents against.
+// They can play in every valid GameMode, with any number of players.
+package bots
+
+import (
+	"github.com/tkw1536/hanabi/model"
+)
+
+// certainlyPlayable checks if the card described by k is known to be playable in the game shown by view.
+func certainlyPlayable(k model.Knowledge, view *model.View) bool {
+	possible := k.Possible.Cards()
+	for _, card := range possible {
+		if view.Classify(card) != model.ClassPlayable {
+			return false
+		}
+	}
+	return len(possible) > 0
+}
+
+// firstHint returns the first legal hint move in moves.
+func firstHint(moves []model.Move) model.Move {
+	for _, move := range moves {
+		if move.Kind == model.MoveHint {
+			return move
+		}
+	}
+	return moves[0]
+}
@@ -0,0 +1,106 @@
+package bots
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tkw1536/hanabi/agent"
+	"github.com/tkw1536/hanabi/model"
+	"github.com/tkw1536/hanabi/sim"
+)
+
+// TestBots documents the expected average scores of the bots in this package.
+// Averages were measured over 200 games per mode, and must not deviate by more than a point.
+func TestBots(t *testing.T) {
+	tests := []struct {
+		name     string
+		players  int
+		newAgent sim.Factory
+		want     map[model.GameMode]float64
+	}{
+		{
+			"Random", 3,
+			func(seed int64, seat int) agent.Agent { return NewRandom(seed*10 + int64(seat)) },
+			map[model.GameMode]float64{model.ModeFiveColor: 1.3, model.ModeSixColor: 1.4, model.ModeRainbow: 1.4, model.ModeDarkRainbow: 1.4},
+		},
+		{
+			"Simple", 3,
+			func(seed int64, seat int) agent.Agent { return &Simple{} },
+			map[model.GameMode]float64{model.ModeFiveColor: 15.7, model.ModeSixColor: 19.2, model.ModeRainbow: 16.2, model.ModeDarkRainbow: 14.8},
+		},
+		{
+			"Cheater", 3,
+			func(seed int64, seat int) agent.Agent { return &Cheater{} },
+			map[model.GameMode]float64{model.ModeFiveColor: 24.9, model.ModeSixColor: 29.9, model.ModeRainbow: 29.9, model.ModeDarkRainbow: 29.4},
+		},
+		{
+			"HatGuesser", 5,
+			func(seed int64, seat int) agent.Agent { return &HatGuesser{} },
+			map[model.GameMode]float64{model.ModeFiveColor: 22.4, model.ModeSixColor: 27.6, model.ModeRainbow: 27.6, model.ModeDarkRainbow: 25.2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &sim.Simulator{
+				Modes:    []model.GameMode{model.ModeFiveColor, model.ModeSixColor, model.ModeRainbow, model.ModeDarkRainbow},
+				Players:  tt.players,
+				Games:    200,
+				NewAgent: tt.newAgent,
+			}
+			stats, err := s.Run()
+			if err != nil {
+				t.Fatalf("Simulator.Run() error = %v", err)
+			}
+			for _, s := range stats {
+				if want := tt.want[s.Mode]; math.Abs(s.Mean-want) > 1 {
+					t.Errorf("%s: average score = %.2f, want %.1f", s.Mode, s.Mean, want)
+				}
+			}
+		})
+	}
+}
+
+// TestBots_Players checks that every bot can play with every supported number of players.
+func TestBots_Players(t *testing.T) {
+	bots := []agent.Agent{NewRandom(1), &Simple{}, &Cheater{}, &HatGuesser{}}
+	for _, bot := range bots {
+		for players := 2; players <= 6; players++ {
+			s := &sim.Simulator{
+				Modes:    model.Modes(),
+				Players:  players,
+				Games:    5,
+				NewAgent: func(seed int64, seat int) agent.Agent { return newLike(bot, seed) },
+			}
+			if _, err := s.Run(); err != nil {
+				t.Errorf("%T with %d players: Simulator.Run() error = %v", bot, players, err)
+			}
+		}
+	}
+}
+
+// newLike returns a new bot of the same type as bot.
+func newLike(bot agent.Agent, seed int64) agent.Agent {
+	switch bot.(type) {
+	case *Random:
+		return NewRandom(seed)
+	case *Simple:
+		return &Simple{}
+	case *Cheater:
+		return &Cheater{}
+	case *HatGuesser:
+		return &HatGuesser{}
+	}
+	panic("newLike: unknown bot")
+}
+
+func TestHatGuesser_encode(t *testing.T) {
+	view := &model.View{Players: make([]model.SeatView, 4)}
+	for value := 0; value < 6; value++ {
+		if got := encode(view, decode(view, value)); got != value {
+			t.Errorf("encode(decode(%d)) = %d", value, got)
+		}
+	}
+	if got := decode(view, -1); got != (action{Kind: model.MoveDiscard, Index: 2}) {
+		t.Errorf("decode(-1) = %v, want discard of card 2", got)
+	}
+}
@@ -0,0 +1,88 @@
+package bots
+
+import (
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Cheater is a bot that sees its own hand.
+// It implements agent.Cheater, and is intended to give an upper bound for the scores of fair agents.
+//
+// On its turn, it makes the first possible move of the following:
+//  1. Play the playable card with the lowest number.
+//  2. Discard a card that is dead or also held by another player, unless the maximum number of hints is available.
+//  3. Give any hint.
+//  4. Discard the least valuable card, preferring non-critical cards with high numbers.
+type Cheater struct{}
+
+// Observe does nothing.
+func (c *Cheater) Observe(event model.Event) {}
+
+// Move is used when the Cheater can not cheat, and plays like Simple.
+func (c *Cheater) Move(view *model.View) model.Move {
+	return (&Simple{}).Move(view)
+}
+
+// Cheat returns the next move of this bot.
+func (c *Cheater) Cheat(state *model.GameState) model.Move {
+	hand := state.Players[state.CurrentPlayer].Hand
+
+	play := -1
+	for i, card := range hand {
+		if state.Classify(card) == model.ClassPlayable && (play == -1 || card.Number < hand[play].Number) {
+			play = i
+		}
+	}
+	if play != -1 {
+		return model.Move{Kind: model.MovePlay, Index: play}
+	}
+
+	discard, value := c.leastValuable(state)
+	if state.Hints < state.Options.MaxHints && value <= 1 {
+		return model.Move{Kind: model.MoveDiscard, Index: discard}
+	}
+	if state.Hints > 0 {
+		return firstHint(state.LegalMoves())
+	}
+	return model.Move{Kind: model.MoveDiscard, Index: discard}
+}
+
+// leastValuable returns the index of the least valuable card in the hand of the current player, along with its value.
+//
+// Dead cards have value 0, cards held by other players or held twice have value 1.
+// Other cards have a higher value, with critical cards being the most valuable.
+func (c *Cheater) leastValuable(state *model.GameState) (index, value int) {
+	current := state.Players[state.CurrentPlayer]
+
+	value = -1
+	for i, card := range current.Hand {
+		class := state.Classify(card)
+
+		var v int
+		switch {
+		case class == model.ClassTrash:
+			v = 0
+		case c.held(state, card, i):
+			v = 1
+		case state.IsCritical(card):
+			v = 10 + int(card.Number)
+		default:
+			v = 2 + int(model.NumberFive-card.Number)
+		}
+		if value == -1 || v < value {
+			index, value = i, v
+		}
+	}
+	return index, value
+}
+
+// held checks if another copy of card is held by any player, excluding the card at index of the current player.
+func (c *Cheater) held(state *model.GameState, card model.Card, index int) bool {
+	for p, player := range state.Players {
+		for i, other := range player.Hand {
+			if other == card && (p != state.CurrentPlayer || i != index) {
+				return true
+			}
+		}
+	}
+	return false
+}
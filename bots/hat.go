@@ -0,0 +1,224 @@
+package bots
+
+import (
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// HatGuesser is a bot that uses the hat guessing strategy by Cox et al.
+//
+// Every hint encodes a recommendation for each player other than the hinting player.
+// A recommendation is to either play or discard a specific card.
+// The hinting player computes the recommendation for every other player from their hand, and hints the sum of them.
+// Because every player can see every other hand, each player can then subtract the recommendations of the others to find their own.
+//
+// Hints are encoded by the player they are given to and if they are color or number hints.
+// This makes 2*(players-1) different hints, and recommendations are restricted to the oldest players-1 cards in a hand.
+// The strategy thus works best with many players.
+//
+// On its turn, a HatGuesser makes the first possible move of the following:
+//  1. Play the recommended card, if no other card has been played since the recommendation.
+//  2. Play the recommended card, if one card has been played since the recommendation and this is not the final misplay.
+//  3. Give a hint.
+//  4. Discard the recommended card.
+//  5. Discard the oldest card.
+//
+// A HatGuesser has to see the game after every move, and implements agent.Watcher.
+type HatGuesser struct {
+	id uuid.UUID
+
+	recommendation    action
+	hasRecommendation bool
+
+	// playsSinceHint is the number of cards played since the last hint
+	playsSinceHint int
+
+	// pending is a hint that has not yet been decoded
+	pending *model.Event
+}
+
+// action represents a card to play or discard
+type action struct {
+	Kind  model.MoveKind
+	Index int
+}
+
+// Observe records the hints given and cards played.
+func (h *HatGuesser) Observe(event model.Event) {
+	switch event.Kind {
+	case model.EventHint:
+		h.pending = &event
+		h.playsSinceHint = 0
+	case model.EventPlay, model.EventMisplay:
+		h.playsSinceHint++
+		fallthrough
+	case model.EventDiscard:
+		if event.Player == h.id {
+			h.hasRecommendation = false
+		}
+	}
+}
+
+// Watch decodes the last hint given, if any.
+func (h *HatGuesser) Watch(view *model.View) {
+	h.id = view.Viewer
+	if h.pending == nil {
+		return
+	}
+
+	hint := *h.pending
+	h.pending = nil
+
+	giver := seat(view, hint.Player)
+	me := view.Me()
+	if giver == me {
+		return
+	}
+
+	value := signal(view, giver, hint)
+	for i, p := range view.Players {
+		if i == giver || i == me {
+			continue
+		}
+		value -= encode(view, recommend(view, p.Hand))
+	}
+	h.recommendation = decode(view, value)
+	h.hasRecommendation = true
+}
+
+// Move returns the next move of this bot.
+func (h *HatGuesser) Move(view *model.View) model.Move {
+	me := view.Me()
+
+	if h.hasRecommendation && h.recommendation.Kind == model.MovePlay && h.recommendation.Index < view.Players[me].HandSize {
+		if h.playsSinceHint == 0 || (h.playsSinceHint == 1 && view.Misplays+1 < view.Options.MaxMisplays) {
+			return model.Move{Kind: model.MovePlay, Index: h.recommendation.Index}
+		}
+	}
+
+	if view.Hints > 0 {
+		value := 0
+		for i, p := range view.Players {
+			if i != me {
+				value += encode(view, recommend(view, p.Hand))
+			}
+		}
+		return hintFor(view, value)
+	}
+
+	if h.hasRecommendation && h.recommendation.Kind == model.MoveDiscard && h.recommendation.Index < view.Players[me].HandSize {
+		return model.Move{Kind: model.MoveDiscard, Index: h.recommendation.Index}
+	}
+	return model.Move{Kind: model.MoveDiscard, Index: 0}
+}
+
+// recommend returns the recommended action for hand.
+// It only takes the oldest len(view.Players)-1 cards into account.
+func recommend(view *model.View, hand []model.Card) action {
+	cards := hand
+	if limit := len(view.Players) - 1; len(cards) > limit {
+		cards = cards[:limit]
+	}
+
+	// play a five, or the lowest playable card
+	play := -1
+	for i, card := range cards {
+		if view.Classify(card) != model.ClassPlayable {
+			continue
+		}
+		if card.Number == model.NumberFive {
+			return action{Kind: model.MovePlay, Index: i}
+		}
+		if play == -1 || card.Number < cards[play].Number {
+			play = i
+		}
+	}
+	if play != -1 {
+		return action{Kind: model.MovePlay, Index: play}
+	}
+
+	// discard a dead card, or the highest non-critical card
+	discard := -1
+	for i, card := range cards {
+		if view.Classify(card) == model.ClassTrash {
+			return action{Kind: model.MoveDiscard, Index: i}
+		}
+		if !view.IsCritical(card) && (discard == -1 || card.Number > cards[discard].Number) {
+			discard = i
+		}
+	}
+	if discard != -1 {
+		return action{Kind: model.MoveDiscard, Index: discard}
+	}
+
+	return action{Kind: model.MoveDiscard, Index: 0}
+}
+
+// encode encodes a recommended action as a number.
+func encode(view *model.View, a action) int {
+	if a.Kind == model.MoveDiscard {
+		return len(view.Players) - 1 + a.Index
+	}
+	return a.Index
+}
+
+// decode decodes a number into a recommended action.
+func decode(view *model.View, value int) action {
+	limit := len(view.Players) - 1
+	value = modulo(value, 2*limit)
+	if value >= limit {
+		return action{Kind: model.MoveDiscard, Index: value - limit}
+	}
+	return action{Kind: model.MovePlay, Index: value}
+}
+
+// hintFor returns the hint by the viewer that encodes value.
+func hintFor(view *model.View, value int) model.Move {
+	value = modulo(value, 2*(len(view.Players)-1))
+
+	target := view.Players[(view.Me()+value/2+1)%len(view.Players)]
+	move := model.Move{Kind: model.MoveHint, Hint: target.Hand[0].Number.Hint(), ToPlayerID: target.ID}
+	if value%2 == 1 {
+		return move
+	}
+
+	for _, color := range view.Mode.Suits() {
+		hint := color.Hint()
+		if !hint.Legal(view.Mode) {
+			continue
+		}
+		for _, card := range target.Hand {
+			if hint.Matches(card, view.Mode) {
+				move.Hint = hint
+				return move
+			}
+		}
+	}
+	return move
+}
+
+// signal returns the value encoded by a hint given by the player at seat giver.
+func signal(view *model.View, giver int, hint model.Event) int {
+	offset := modulo(seat(view, hint.ToPlayerID)-giver, len(view.Players))
+
+	value := 2 * (offset - 1)
+	if hint.Hint.IsNumberHint() {
+		value++
+	}
+	return value
+}
+
+// seat returns the index of the player with the provided id.
+func seat(view *model.View, id uuid.UUID) int {
+	for i, p := range view.Players {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// modulo returns the non-negative remainder of a divided by b.
+func modulo(a, b int) int {
+	return ((a % b) + b) % b
+}
@@ -0,0 +1,26 @@
+package bots
+
+import (
+	"math/rand"
+
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Random is a bot that makes a random legal move.
+type Random struct {
+	rand *rand.Rand
+}
+
+// NewRandom creates a new Random bot that uses the provided seed for randomness.
+func NewRandom(seed int64) *Random {
+	return &Random{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Observe does nothing.
+func (r *Random) Observe(event model.Event) {}
+
+// Move returns a random move from View.LegalMoves.
+func (r *Random) Move(view *model.View) model.Move {
+	moves := view.LegalMoves()
+	return moves[r.rand.Intn(len(moves))]
+}
@@ -0,0 +1,75 @@
+package bots
+
+import (
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Simple is a bot that only plays cards it knows to be playable.
+//
+// On its turn, it makes the first possible move of the following:
+//  1. Play the first card that is certainly playable based on its Knowledge.
+//  2. Hint the first playable card of the next players that is not yet known to be playable.
+//  3. Discard the oldest card.
+//  4. Give any hint.
+type Simple struct{}
+
+// Observe does nothing.
+func (s *Simple) Observe(event model.Event) {}
+
+// Move returns the next move of this bot.
+func (s *Simple) Move(view *model.View) model.Move {
+	me := view.Me()
+
+	for i, k := range view.Players[me].Knowledge {
+		if certainlyPlayable(k, view) {
+			return model.Move{Kind: model.MovePlay, Index: i}
+		}
+	}
+
+	if view.Hints > 0 {
+		for offset := 1; offset < len(view.Players); offset++ {
+			player := view.Players[(me+offset)%len(view.Players)]
+			for i, card := range player.Hand {
+				if view.Classify(card) != model.ClassPlayable || certainlyPlayable(player.Knowledge[i], view) {
+					continue
+				}
+				if hint, ok := bestHint(view, card, player.Knowledge[i]); ok {
+					return model.Move{Kind: model.MoveHint, Hint: hint, ToPlayerID: player.ID}
+				}
+			}
+		}
+	}
+
+	if view.Hints < view.Options.MaxHints {
+		return model.Move{Kind: model.MoveDiscard, Index: 0}
+	}
+	return firstHint(view.LegalMoves())
+}
+
+// bestHint returns the legal hint touching card that tells its owner the most about it.
+// A hint that makes the card certainly playable is preferred.
+// When no hint adds to the knowledge k of the owner, returns false.
+func bestHint(view *model.View, card model.Card, k model.Knowledge) (hint model.Hint, ok bool) {
+	mode := view.Mode
+	candidates := []model.Hint{card.Number.Hint()}
+	for _, color := range mode.Suits() {
+		candidates = append(candidates, color.Hint())
+	}
+
+	best := k.Possible.Len()
+	for _, candidate := range candidates {
+		if !candidate.Legal(mode) || !candidate.Matches(card, mode) {
+			continue
+		}
+
+		learned := k
+		learned.Learn(candidate, true, mode)
+		if certainlyPlayable(learned, view) {
+			return candidate, true
+		}
+		if size := learned.Possible.Len(); size < best {
+			hint, best, ok = candidate, size, true
+		}
+	}
+	return hint, ok
+}
@@ -0,0 +1,30 @@
+// Command hanabi-server hosts games of Hanabi using an HTTP JSON API.
+//
+// See the documentation of the server package for the endpoints provided.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tkw1536/hanabi/server"
+)
+
+var addr = flag.String("addr", "localhost:8080", "address to listen on")
+var idle = flag.Duration("idle", time.Hour, "remove games that have not been used for this long")
+
+func main() {
+	flag.Parse()
+
+	handler := server.New()
+	go func() {
+		for range time.Tick(*idle / 10) {
+			handler.Collect(*idle)
+		}
+	}()
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
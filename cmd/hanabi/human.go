@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tkw1536/hanabi/model"
+)
+
+// human is an agent.Agent that is played by a human at the terminal
+type human struct {
+	name string
+
+	in  *bufio.Scanner
+	out *renderer
+
+	// names are the names of all players, by seat
+	names []string
+
+	// hotseat indicates that more than one human shares the terminal.
+	// Then the screen is cleared before and after each turn.
+	hotseat bool
+
+	// rejected indicates that the last move of this human was rejected, and they are being asked again
+	rejected bool
+
+	// events are the events since the last turn of this human
+	events []model.Event
+}
+
+// Observe stores event to show it on the next turn.
+func (h *human) Observe(event model.Event) {
+	switch event.Kind {
+	case model.EventPlay, model.EventMisplay, model.EventDiscard, model.EventHint:
+		h.events = append(h.events, event)
+	}
+}
+
+// Move shows the game to the human and reads a move.
+func (h *human) Move(view *model.View) model.Move {
+	if h.hotseat && !h.rejected {
+		h.out.clear()
+		h.prompt(fmt.Sprintf("Pass the terminal to %s and press Enter.", h.name))
+		h.out.clear()
+	}
+	h.rejected = false
+
+	if len(h.events) > 0 {
+		fmt.Fprintln(h.out.w, "Since your last turn:")
+		for _, event := range h.events {
+			fmt.Fprintf(h.out.w, "  %s\n", h.out.event(event, view, h.names))
+		}
+		fmt.Fprintln(h.out.w)
+		h.events = nil
+	}
+
+	h.out.view(view, h.names)
+	for {
+		line := h.prompt(fmt.Sprintf("%s> ", h.name))
+		if line == "q" || line == "quit" {
+			fmt.Fprintln(h.out.w, "Bye.")
+			os.Exit(0)
+		}
+
+		move, err := parseCommand(line, view)
+		if err != nil {
+			fmt.Fprintln(h.out.w, err)
+			continue
+		}
+		return move
+	}
+}
+
+// Reject shows err to the human, who is then asked again.
+// The terminal does not need to be passed on in between, so the error remains visible.
+func (h *human) Reject(move model.Move, err error) {
+	fmt.Fprintf(h.out.w, "Illegal move: %s\n", err)
+	h.rejected = true
+}
+
+// prompt shows text and reads a line of input.
+// When input is closed, the program exits.
+func (h *human) prompt(text string) string {
+	fmt.Fprint(h.out.w, text)
+	if !h.in.Scan() {
+		fmt.Fprintln(h.out.w)
+		os.Exit(0)
+	}
+	return strings.TrimSpace(h.in.Text())
+}
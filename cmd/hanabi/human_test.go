@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tkw1536/hanabi/model"
+)
+
+func Test_human_Move(t *testing.T) {
+	state := &model.GameState{Mode: model.ModeFiveColor}
+	for i := 0; i < 2; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := state.Start(42); err != nil {
+		t.Fatal(err)
+	}
+	view, err := state.PlayerView(state.Players[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	h := &human{
+		name:    "Alice",
+		names:   []string{"Alice", "Bob"},
+		in:      bufio.NewScanner(strings.NewReader("\nd1\np1\n\nd2\n")),
+		out:     &renderer{w: &out},
+		hotseat: true,
+	}
+
+	// the screen is cleared before the terminal is passed on, even without colors
+	if got := h.Move(view); got != (model.Move{Kind: model.MoveDiscard, Index: 0}) {
+		t.Errorf("human.Move() = %v, want discard of card 1", got)
+	}
+	if !strings.HasPrefix(out.String(), strings.Repeat("\n", clearLines)+"Pass the terminal to Alice") {
+		t.Errorf("human.Move() did not clear the screen before passing the terminal")
+	}
+
+	// after a rejected move, the same human is asked again right away
+	out.Reset()
+	h.Reject(model.Move{}, errors.New("not allowed"))
+	if got := h.Move(view); got != (model.Move{Kind: model.MovePlay, Index: 0}) {
+		t.Errorf("human.Move() = %v, want play of card 1", got)
+	}
+	if output := out.String(); !strings.HasPrefix(output, "Illegal move: not allowed\n") || strings.Contains(output, "Pass the terminal") {
+		t.Errorf("human.Move() after human.Reject() = %q, want the error to remain visible", output)
+	}
+}
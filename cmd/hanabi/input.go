@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// errSyntax is returned when a command can not be parsed
+var errSyntax = errors.New("Unknown command, use 'p2' to play, 'd1' to discard or 'h 3 red' to hint")
+
+// parseCommand parses a move entered by the viewer of view.
+//
+// Cards and players are numbered starting at 1.
+// The following commands are supported:
+//
+//	p2         play the second card
+//	d1         discard the first card
+//	h 3 red    hint the third player about their red cards
+//	h 3 4      hint the third player about their fours
+//
+// Moves in the notation of model.View.ParseMove, like "clue p3 red", are also accepted.
+func parseCommand(input string, view *model.View) (model.Move, error) {
+	if move, err := view.ParseMove(input); err == nil {
+		return move, nil
+	}
+
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return model.Move{}, errSyntax
+	}
+
+	// allow both 'p2' and 'p 2'
+	command := fields[0][:1]
+	if rest := fields[0][1:]; rest != "" {
+		fields = append([]string{command, rest}, fields[1:]...)
+	}
+	args := fields[1:]
+
+	switch {
+	case (command == "p" || command == "d") && len(args) == 1:
+		index, err := parseIndex(args[0], view.Players[view.Me()].HandSize, "card")
+		if err != nil {
+			return model.Move{}, err
+		}
+		if command == "p" {
+			return model.Move{Kind: model.MovePlay, Index: index}, nil
+		}
+		return model.Move{Kind: model.MoveDiscard, Index: index}, nil
+
+	case command == "h" && len(args) == 2:
+		seat, err := parseIndex(args[0], len(view.Players), "player")
+		if err != nil {
+			return model.Move{}, err
+		}
+		hint, err := parseHint(args[1], view.Mode)
+		if err != nil {
+			return model.Move{}, err
+		}
+		return model.Move{Kind: model.MoveHint, Hint: hint, ToPlayerID: view.Players[seat].ID}, nil
+	}
+
+	return model.Move{}, errSyntax
+}
+
+// parseIndex parses a 1-based number between 1 and count into a 0-based index.
+func parseIndex(input string, count int, what string) (int, error) {
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 1 || n > count {
+		return 0, errors.Errorf("Expected a %s between 1 and %d", what, count)
+	}
+	return n - 1, nil
+}
+
+// parseHint parses a color or number hint in mode.
+// Besides the notation of model.ParseHint, colors may be abbreviated to any unique prefix.
+func parseHint(input string, mode model.GameMode) (model.Hint, error) {
+	if hint, err := model.ParseHint(input); err == nil {
+		return hint, nil
+	}
+	if _, err := strconv.Atoi(input); err == nil {
+		return model.Hint{}, errors.New("Expected a number between 1 and 5")
+	}
+
+	var matches []model.CardColor
+	for _, color := range mode.Suits() {
+		if strings.HasPrefix(string(color), input) {
+			matches = append(matches, color)
+		}
+	}
+	if len(matches) != 1 {
+		return model.Hint{}, errors.Errorf("Unknown color %q", input)
+	}
+	return matches[0].Hint(), nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/model"
+)
+
+func Test_parseCommand(t *testing.T) {
+	me := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	other := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	view := &model.View{
+		Viewer: me,
+		Mode:   model.ModeRainbow,
+		Players: []model.SeatView{
+			{ID: me, HandSize: 4},
+			{ID: other, HandSize: 4},
+		},
+	}
+
+	tests := []struct {
+		input   string
+		want    model.Move
+		wantErr bool
+	}{
+		{"p2", model.Move{Kind: model.MovePlay, Index: 1}, false},
+		{"p 2", model.Move{Kind: model.MovePlay, Index: 1}, false},
+		{"P4", model.Move{Kind: model.MovePlay, Index: 3}, false},
+		{"d1", model.Move{Kind: model.MoveDiscard, Index: 0}, false},
+		{"h 2 red", model.Move{Kind: model.MoveHint, Hint: model.ColorRed.Hint(), ToPlayerID: other}, false},
+		{"h2 Re", model.Move{Kind: model.MoveHint, Hint: model.ColorRed.Hint(), ToPlayerID: other}, false},
+		{"h 2 3", model.Move{Kind: model.MoveHint, Hint: model.NumberThree.Hint(), ToPlayerID: other}, false},
+		{"h 1 rainbow", model.Move{Kind: model.MoveHint, Hint: model.ColorRainbow.Hint(), ToPlayerID: me}, false},
+		{"h 2 r", model.Move{Kind: model.MoveHint, Hint: model.ColorRed.Hint(), ToPlayerID: other}, false},
+		{"h 2 m", model.Move{Kind: model.MoveHint, Hint: model.ColorRainbow.Hint(), ToPlayerID: other}, false},
+		{"play 3", model.Move{Kind: model.MovePlay, Index: 2}, false},
+		{"clue p2 blue", model.Move{Kind: model.MoveHint, Hint: model.ColorBlue.Hint(), ToPlayerID: other}, false},
+
+		{"", model.Move{}, true},
+		{"x", model.Move{}, true},
+		{"p", model.Move{}, true},
+		{"p5", model.Move{}, true},
+		{"d0", model.Move{}, true},
+		{"h 3 red", model.Move{}, true},
+		{"h 2 purple", model.Move{}, true},
+		{"h 2 6", model.Move{}, true},
+		{"h 2", model.Move{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseCommand(tt.input, view)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCommand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
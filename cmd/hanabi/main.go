@@ -0,0 +1,105 @@
+// Command hanabi plays Hanabi in the terminal.
+//
+// Humans share the terminal in hot-seat mode and may be joined by bots.
+// Moves are entered in a short notation, for example 'p2' plays the second card,
+// 'd1' discards the first card and 'h 3 red' hints the third player about their red cards.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/tkw1536/hanabi/agent"
+	"github.com/tkw1536/hanabi/bots"
+	"github.com/tkw1536/hanabi/model"
+)
+
+var (
+	mode   = flag.String("mode", string(model.ModeFiveColor), "game mode to play, e.g. 'five-color' or 'rainbow'")
+	humans = flag.Int("humans", 1, "number of human players")
+	nbots  = flag.Int("bots", 1, "number of bot players")
+	bot    = flag.String("bot", "simple", "kind of bot to play with, one of 'simple', 'hat', 'random' or 'cheater'")
+	seed   = flag.Int64("seed", 0, "seed to shuffle the deck with, 0 for a random seed")
+	plain  = flag.Bool("plain", false, "do not use colors or ANSI escape codes")
+)
+
+func main() {
+	flag.Parse()
+
+	var gameMode model.GameMode
+	if err := gameMode.UnmarshalText([]byte(*mode)); err != nil || gameMode == "" {
+		log.Fatalf("unknown mode %q, expected one of %v", *mode, model.Modes())
+	}
+	if *humans < 0 || *nbots < 0 {
+		log.Fatal("number of players must not be negative")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	out := &renderer{w: os.Stdout, color: !*plain}
+	in := bufio.NewScanner(os.Stdin)
+
+	var agents []agent.Agent
+	var names []string
+	for i := 0; i < *humans; i++ {
+		name := fmt.Sprintf("Player %d", i+1)
+		agents = append(agents, &human{name: name, in: in, out: out, hotseat: *humans > 1})
+		names = append(names, name)
+	}
+	for i := 0; i < *nbots; i++ {
+		b, err := newBot(*bot, *seed+int64(i))
+		if err != nil {
+			log.Fatal(err)
+		}
+		agents = append(agents, b)
+		names = append(names, fmt.Sprintf("Bot %d", i+1))
+	}
+
+	// every human needs to know the names of all players
+	for _, a := range agents {
+		if h, ok := a.(*human); ok {
+			h.names = names
+		}
+	}
+
+	runner := &agent.Runner{Mode: gameMode, Policy: agent.PolicyRetry, MaxRetries: 1 << 30}
+	result, err := runner.Run(*seed, agents...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out.clear()
+	view, err := result.State.PlayerView(result.State.Players[0].ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	view.Players[0].Hand = result.State.Players[0].Hand // the game is over, reveal every hand
+
+	for _, event := range result.History {
+		if line := out.event(event.Redact(view.Viewer), view, names); line != "" {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+	out.view(view, names)
+}
+
+// newBot creates a new bot of the provided kind
+func newBot(kind string, seed int64) (agent.Agent, error) {
+	switch kind {
+	case "simple":
+		return &bots.Simple{}, nil
+	case "hat":
+		return &bots.HatGuesser{}, nil
+	case "random":
+		return bots.NewRandom(seed), nil
+	case "cheater":
+		return &bots.Cheater{}, nil
+	}
+	return nil, fmt.Errorf("unknown bot %q", kind)
+}
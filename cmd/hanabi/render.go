@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// renderer renders games to a terminal
+type renderer struct {
+	w     io.Writer
+	color bool // use ANSI escape codes for colors and to clear the screen
+}
+
+// ansiColors are the ANSI escape codes used for the different colors
+var ansiColors = map[model.CardColor]string{
+	model.ColorBlue:    "\x1b[1;34m",
+	model.ColorGreen:   "\x1b[1;32m",
+	model.ColorRed:     "\x1b[1;31m",
+	model.ColorWhite:   "\x1b[1;37m",
+	model.ColorYellow:  "\x1b[1;33m",
+	model.ColorRainbow: "\x1b[1;35m",
+}
+
+const ansiReset = "\x1b[0m"
+const ansiClear = "\x1b[H\x1b[2J"
+
+// paint paints text in color.
+func (r *renderer) paint(color model.CardColor, text string) string {
+	code, ok := ansiColors[color]
+	if !r.color || !ok {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// clearLines is the number of blank lines used to clear the screen without ANSI escape codes
+const clearLines = 100
+
+// clear clears the screen.
+// Without colors, it prints blank lines instead of an ANSI escape code.
+func (r *renderer) clear() {
+	if !r.color {
+		fmt.Fprint(r.w, strings.Repeat("\n", clearLines))
+		return
+	}
+	fmt.Fprint(r.w, ansiClear)
+}
+
+// card renders a single card, e.g. "R3"
+func (r *renderer) card(card model.Card) string {
+	return r.paint(card.Color, strings.ToUpper(card.Short()))
+}
+
+// knowledge renders what is known about a card, e.g. "R?" or "?4"
+func (r *renderer) knowledge(k model.Knowledge) string {
+	var color model.CardColor
+	var number model.CardNumber
+	for i, c := range k.Possible.Cards() {
+		if i == 0 {
+			color, number = c.Color, c.Number
+			continue
+		}
+		if c.Color != color {
+			color = model.ColorUnspecified
+		}
+		if c.Number != number {
+			number = model.NumberUnspecified
+		}
+	}
+
+	return r.card(model.Card{Color: color, Number: number})
+}
+
+// view renders the game as seen by the viewer of view.
+func (r *renderer) view(view *model.View, names []string) {
+	if view.Outcome != model.OutcomeNone {
+		fmt.Fprintf(r.w, "%s after %d turns, score %d.\n\n", view.Outcome, view.Turn, view.Score())
+	} else {
+		fmt.Fprintf(r.w, "Turn %d, %s to play.\n\n", view.Turn+1, names[view.CurrentPlayer])
+	}
+
+	piles := make([]string, 0, len(view.ColorPiles))
+	for _, color := range view.Mode.Suits() {
+		piles = append(piles, r.paint(color, fmt.Sprintf("%s %d", color, view.ColorPiles[color])))
+	}
+	fmt.Fprintf(r.w, "Piles:    %s\n", strings.Join(piles, "  "))
+
+	discarded := make([]string, len(view.Discarded))
+	for i, card := range view.Discarded {
+		discarded[i] = r.card(card)
+	}
+	fmt.Fprintf(r.w, "Discards: %s\n", strings.Join(discarded, " "))
+
+	fmt.Fprintf(r.w, "Hints:    %s\n", tokens(int(view.Hints), int(view.Options.MaxHints)))
+	fmt.Fprintf(r.w, "Misplays: %s\n", tokens(int(view.Misplays), int(view.Options.MaxMisplays)))
+	fmt.Fprintf(r.w, "Stack:    %d cards\n\n", view.StackSize)
+
+	for i, p := range view.Players {
+		cards := make([]string, p.HandSize)
+		for j := range cards {
+			if p.Hand == nil {
+				cards[j] = r.knowledge(p.Knowledge[j])
+			} else {
+				cards[j] = r.card(p.Hand[j])
+			}
+			if p.Knowledge[j].Clued {
+				cards[j] += "*"
+			} else {
+				cards[j] += " "
+			}
+		}
+		fmt.Fprintf(r.w, "%d. %-12s %s\n", i+1, names[i], strings.Join(cards, " "))
+	}
+	fmt.Fprintln(r.w)
+}
+
+// tokens renders n out of max tokens
+func tokens(n, max int) string {
+	if n > max {
+		n = max
+	}
+	return strings.Repeat("●", n) + strings.Repeat("○", max-n) + fmt.Sprintf(" (%d/%d)", n, max)
+}
+
+// event renders a single event that happened in the game of view
+func (r *renderer) event(event model.Event, view *model.View, names []string) string {
+	player := names[seatOf(view, event.Player)]
+	switch event.Kind {
+	case model.EventPlay:
+		return fmt.Sprintf("%s played %s", player, r.card(event.Card))
+	case model.EventMisplay:
+		return fmt.Sprintf("%s misplayed %s", player, r.card(event.Card))
+	case model.EventDiscard:
+		return fmt.Sprintf("%s discarded %s", player, r.card(event.Card))
+	case model.EventHint:
+		hint := event.Hint.Number.String()
+		if event.Hint.IsColorHint() {
+			hint = r.paint(event.Hint.Color, event.Hint.Color.String())
+		}
+		touched := make([]string, len(event.Touched))
+		for i, index := range event.Touched {
+			touched[i] = fmt.Sprint(index + 1)
+		}
+		return fmt.Sprintf("%s hinted %s about %s (cards %s)", player, names[seatOf(view, event.ToPlayerID)], hint, strings.Join(touched, ", "))
+	case model.EventGameOver:
+		return fmt.Sprintf("Game over (%s) with a score of %d", event.Outcome, event.Score)
+	}
+	return ""
+}
+
+// seatOf returns the seat of the player with the provided id in view
+func seatOf(view *model.View, id uuid.UUID) int {
+	for i, p := range view.Players {
+		if p.ID == id {
+			return i
+		}
+	}
+	panic("seatOf: unknown player")
+}
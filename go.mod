@@ -1,3 +1,9 @@
 module github.com/tkw1536/hanabi
 
 go 1.15
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/gorilla/websocket v1.5.0
+	github.com/pkg/errors v0.9.1
+)
@@ -0,0 +1,205 @@
+package hanablive
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// ErrInvalidAction is returned when an action can not be converted into a move.
+var ErrInvalidAction = errors.New("hanablive: Invalid action")
+
+// ErrInvalidCard is returned when a card does not exist in the variant of the game.
+var ErrInvalidCard = errors.New("hanablive: Invalid card")
+
+// ErrUnsupportedOptions is returned when the RuleOptions of a game can not be represented by Options.
+var ErrUnsupportedOptions = errors.New("hanablive: Unsupported rule options")
+
+// rules returns the RuleOptions of a game with these options.
+// These are the default rules, except for the options supported by Options.
+func (options Options) rules() *model.RuleOptions {
+	rules := model.DefaultRuleOptions()
+	rules.OneExtraCard = options.OneExtraCard
+	rules.OneLessCard = options.OneLessCard
+	rules.EmptyHints = options.EmptyClues
+	return rules
+}
+
+// State converts this game into a GameState.
+//
+// A new player is added for every name in Players, and the game is started with Deck.
+// The game uses the default rules, except for the options supported by Options.
+// Then each action is applied in order.
+// When an action of type ActionGameOver is encountered, the remaining actions are ignored.
+func (game *Game) State() (*model.GameState, error) {
+	v, err := variantByName(game.Options.Variant)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert the deck
+	deck := make([]model.Card, len(game.Deck))
+	for i, c := range game.Deck {
+		if c.SuitIndex < 0 || c.SuitIndex >= len(v.Suits) {
+			return nil, ErrInvalidCard
+		}
+		deck[i] = model.Card{Color: v.Suits[c.SuitIndex], Number: model.CardNumber(c.Rank)}
+		if !deck[i].Valid() {
+			return nil, ErrInvalidCard
+		}
+	}
+
+	// create and start the game
+	state := &model.GameState{Mode: v.Mode, Options: game.Options.rules()}
+	for range game.Players {
+		if _, err := state.AddPlayer(); err != nil {
+			return nil, err
+		}
+	}
+	if err := state.StartWithDeck(deck); err != nil {
+		return nil, err
+	}
+
+	// apply all the actions
+	hands := newOrders(state)
+	for i, action := range game.Actions {
+		if action.Type == ActionGameOver {
+			break
+		}
+
+		player := state.CurrentPlayer
+		move, err := v.move(state, hands, action)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Action %d", i)
+		}
+		if err := state.Apply(move); err != nil {
+			return nil, errors.Wrapf(err, "Action %d", i)
+		}
+		if move.Kind == model.MovePlay || move.Kind == model.MoveDiscard {
+			hands.take(player, move.Index)
+		}
+	}
+
+	return state, nil
+}
+
+// move converts an action into a move in a game.
+func (v *variant) move(state *model.GameState, hands *orders, action Action) (move model.Move, err error) {
+	switch action.Type {
+	case ActionPlay, ActionDiscard:
+		move.Kind = model.MovePlay
+		if action.Type == ActionDiscard {
+			move.Kind = model.MoveDiscard
+		}
+		move.Index = hands.find(state.CurrentPlayer, action.Target)
+		if move.Index == -1 {
+			return move, ErrInvalidAction
+		}
+	case ActionColorClue, ActionRankClue:
+		move.Kind = model.MoveHint
+		if action.Target < 0 || action.Target >= len(state.Players) {
+			return move, ErrInvalidAction
+		}
+		move.ToPlayerID = state.Players[action.Target].ID
+		if action.Type == ActionRankClue {
+			move.Hint = model.CardNumber(action.Value).Hint()
+		} else {
+			if action.Value < 0 || action.Value >= len(v.ClueColors) {
+				return move, ErrInvalidAction
+			}
+			move.Hint = v.ClueColors[action.Value].Hint()
+		}
+	default:
+		return move, ErrInvalidAction
+	}
+	return move, nil
+}
+
+// FromState converts a GameState into a Game.
+//
+// Names are the names of the players in the game.
+// When names is nil, the players are named "Player 1", "Player 2", and so on.
+//
+// Games without Options are assumed to use the DefaultRuleOptions.
+// When the rules of the game differ from the defaults in other ways than supported by Options, returns ErrUnsupportedOptions.
+func FromState(state *model.GameState, names []string) (*Game, error) {
+	v, err := variantByMode(state.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := state.Options
+	if rules == nil {
+		rules = model.DefaultRuleOptions()
+	}
+	options := Options{
+		Variant:      v.Name,
+		OneExtraCard: rules.OneExtraCard,
+		OneLessCard:  rules.OneLessCard,
+		EmptyClues:   rules.EmptyHints,
+	}
+	if !reflect.DeepEqual(options.rules(), rules) {
+		return nil, ErrUnsupportedOptions
+	}
+
+	if names == nil {
+		for i := range state.Players {
+			names = append(names, "Player "+strconv.Itoa(i+1))
+		}
+	}
+	if len(names) != len(state.Players) {
+		return nil, errors.New("hanablive: Number of names does not match number of players")
+	}
+
+	game := &Game{
+		Players: names,
+		Deck:    make([]Card, len(state.Deck)),
+		Actions: make([]Action, 0, len(state.Moves)),
+		Options: options,
+	}
+
+	for i, c := range state.Deck {
+		game.Deck[i] = Card{SuitIndex: indexOf(v.Suits, c.Color), Rank: int(c.Number)}
+	}
+
+	// replay the game from the beginning to find the orders of the cards
+	replay, err := state.Branch(0)
+	if err != nil {
+		return nil, err
+	}
+	hands := newOrders(replay)
+	for i, move := range state.Moves {
+		player := replay.CurrentPlayer
+
+		var action Action
+		switch move.Kind {
+		case model.MovePlay:
+			action = Action{Type: ActionPlay, Target: hands.take(player, move.Index)}
+		case model.MoveDiscard:
+			action = Action{Type: ActionDiscard, Target: hands.take(player, move.Index)}
+		case model.MoveHint:
+			action.Target = -1
+			for j, p := range replay.Players {
+				if p.ID == move.ToPlayerID {
+					action.Target = j
+				}
+			}
+			if move.Hint.IsNumberHint() {
+				action.Type = ActionRankClue
+				action.Value = int(move.Hint.Number)
+			} else {
+				action.Type = ActionColorClue
+				action.Value = indexOf(v.ClueColors, move.Hint.Color)
+			}
+		}
+		game.Actions = append(game.Actions, action)
+
+		if err := replay.Apply(move); err != nil {
+			return nil, errors.Wrapf(err, "Move %d", i)
+		}
+	}
+
+	return game, nil
+}
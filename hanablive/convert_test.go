@@ -0,0 +1,231 @@
+package hanablive
+
+import (
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// readFixture reads the fixture with the provided name.
+func readFixture(t *testing.T, name string) *Game {
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	game, err := Read(f)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	return game
+}
+
+func TestGame_State(t *testing.T) {
+	game := readFixture(t, "no-variant.json")
+
+	state, err := game.State()
+	if err != nil {
+		t.Fatalf("Game.State() error = %v", err)
+	}
+
+	if state.Mode != model.ModeFiveColor {
+		t.Errorf("Mode = %v, want %v", state.Mode, model.ModeFiveColor)
+	}
+	if state.Turn != 7 {
+		t.Errorf("Turn = %v, want 7", state.Turn)
+	}
+	if state.CurrentPlayer != 1 {
+		t.Errorf("CurrentPlayer = %v, want 1", state.CurrentPlayer)
+	}
+	if state.Score() != 3 {
+		t.Errorf("Score() = %v, want 3", state.Score())
+	}
+	if state.Hints != model.MaxHints {
+		t.Errorf("Hints = %v, want %v", state.Hints, model.MaxHints)
+	}
+
+	wantDiscarded := []model.Card{{Color: model.ColorYellow, Number: model.NumberOne}, {Color: model.ColorRed, Number: model.NumberOne}}
+	if !reflect.DeepEqual(state.Discarded, wantDiscarded) {
+		t.Errorf("Discarded = %v, want %v", state.Discarded, wantDiscarded)
+	}
+
+	// Alice played orders 0 and 1 and discarded order 10
+	wantHand := []model.Card{
+		{Color: model.ColorGreen, Number: model.NumberTwo},
+		{Color: model.ColorBlue, Number: model.NumberFive},
+		{Color: model.ColorWhite, Number: model.NumberThree},
+		state.Deck[11],
+		state.Deck[14],
+	}
+	if !reflect.DeepEqual(state.Players[0].Hand, wantHand) {
+		t.Errorf("Players[0].Hand = %v, want %v", state.Players[0].Hand, wantHand)
+	}
+}
+
+func TestFromState(t *testing.T) {
+	game := readFixture(t, "no-variant.json")
+
+	state, err := game.State()
+	if err != nil {
+		t.Fatalf("Game.State() error = %v", err)
+	}
+
+	exported, err := FromState(state, game.Players)
+	if err != nil {
+		t.Fatalf("FromState() error = %v", err)
+	}
+	if !reflect.DeepEqual(exported, game) {
+		t.Errorf("FromState() = %v, want %v", exported, game)
+	}
+}
+
+func TestFromState_roundtrip(t *testing.T) {
+	for _, mode := range []model.GameMode{model.ModeFiveColor, model.ModeSixColor, model.ModeRainbow, model.ModeDarkRainbow} {
+		t.Run(string(mode), func(t *testing.T) {
+			state := &model.GameState{Mode: mode}
+			for i := 0; i < 3; i++ {
+				if _, err := state.AddPlayer(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := state.Start(42); err != nil {
+				t.Fatal(err)
+			}
+
+			random := rand.New(rand.NewSource(42))
+			for !state.Over() {
+				moves := state.LegalMoves()
+				if err := state.Apply(moves[random.Intn(len(moves))]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			game, err := FromState(state, nil)
+			if err != nil {
+				t.Fatalf("FromState() error = %v", err)
+			}
+			imported, err := game.State()
+			if err != nil {
+				t.Fatalf("Game.State() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(imported.Deck, state.Deck) {
+				t.Error("Game.State() did not preserve the deck")
+			}
+			if imported.Turn != state.Turn || imported.Score() != state.Score() || imported.Outcome != state.Outcome {
+				t.Error("Game.State() did not preserve the moves")
+			}
+			for i, p := range imported.Players {
+				if !reflect.DeepEqual(p.Hand, state.Players[i].Hand) {
+					t.Errorf("Players[%d].Hand = %v, want %v", i, p.Hand, state.Players[i].Hand)
+				}
+			}
+		})
+	}
+}
+
+func TestFromState_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(state *model.GameState)
+		wantErr error
+	}{
+		{"not started", func(state *model.GameState) { *state = model.GameState{Mode: state.Mode, Players: state.Players} }, model.ErrGameNotStarted},
+		{"max hints", func(state *model.GameState) { state.Options.MaxHints = 5 }, ErrUnsupportedOptions},
+		{"max misplays", func(state *model.GameState) { state.Options.MaxMisplays = 4 }, ErrUnsupportedOptions},
+		{"hand sizes", func(state *model.GameState) { state.Options.HandSizes = map[int]int{2: 3} }, ErrUnsupportedOptions},
+		{"score zero on strikeout", func(state *model.GameState) { state.Options.ScoreZeroOnStrikeout = true }, ErrUnsupportedOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := readFixture(t, "no-variant.json").State()
+			if err != nil {
+				t.Fatalf("Game.State() error = %v", err)
+			}
+			tt.modify(state)
+			if _, err := FromState(state, nil); errors.Cause(err) != tt.wantErr {
+				t.Errorf("FromState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGame_State_errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(game *Game)
+	}{
+		{"unknown variant", func(game *Game) { game.Options.Variant = "Unknown" }},
+		{"invalid suit", func(game *Game) { game.Deck[0].SuitIndex = 5 }},
+		{"invalid rank", func(game *Game) { game.Deck[0].Rank = 6 }},
+		{"unknown card order", func(game *Game) { game.Actions[0].Target = 5 }},
+		{"invalid clue color", func(game *Game) { game.Actions[1].Value = 5 }},
+		{"invalid action", func(game *Game) { game.Actions[0].Type = 5 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := readFixture(t, "no-variant.json")
+			tt.modify(game)
+			if _, err := game.State(); err == nil {
+				t.Error("Game.State() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestGame_State_rainbow(t *testing.T) {
+	game := readFixture(t, "rainbow.json")
+
+	state, err := game.State()
+	if err != nil {
+		t.Fatalf("Game.State() error = %v", err)
+	}
+
+	if state.Mode != model.ModeRainbow {
+		t.Errorf("Mode = %v, want %v", state.Mode, model.ModeRainbow)
+	}
+	if state.ColorPiles[model.ColorRainbow] != model.NumberOne {
+		t.Errorf("ColorPiles[Rainbow] = %v, want %v", state.ColorPiles[model.ColorRainbow], model.NumberOne)
+	}
+
+	// the red clue touched the rainbow card, and the red 2 that is still in the hand
+	if knowledge := state.Players[0].Knowledge[0]; !knowledge.Clued || knowledge.Possible.Len() != 10 {
+		t.Errorf("Players[0].Knowledge[0] = %v, want a clued card that is red or rainbow", knowledge)
+	}
+
+	exported, err := FromState(state, game.Players)
+	if err != nil {
+		t.Fatalf("FromState() error = %v", err)
+	}
+	if !reflect.DeepEqual(exported, game) {
+		t.Errorf("FromState() = %v, want %v", exported, game)
+	}
+}
+
+func TestGame_State_options(t *testing.T) {
+	game := readFixture(t, "no-variant.json")
+	game.Options.OneLessCard = true
+	game.Options.EmptyClues = true
+	game.Actions = []Action{{Type: ActionColorClue, Target: 1, Value: 1}}
+
+	state, err := game.State()
+	if err != nil {
+		t.Fatalf("Game.State() error = %v", err)
+	}
+	if len(state.Players[0].Hand) != 4 {
+		t.Errorf("len(Hand) = %v, want 4", len(state.Players[0].Hand))
+	}
+
+	exported, err := FromState(state, game.Players)
+	if err != nil {
+		t.Fatalf("FromState() error = %v", err)
+	}
+	if !reflect.DeepEqual(exported.Options, game.Options) {
+		t.Errorf("FromState().Options = %v, want %v", exported.Options, game.Options)
+	}
+}
@@ -0,0 +1,208 @@
+// Package hanablive converts games to and from the JSON format used by hanab.live to export games.
+//
+// Only the parts of the format needed to reconstruct a game are supported.
+// These are the names of the players, the deck, the actions taken and the variant of the game.
+//
+// hanab.live uses different suits than this package.
+// Red, Yellow, Green and Blue map to the respective colors, Purple maps to White.
+// The sixth suit of each variant, i.e. Teal in "6 Suits" and the Rainbow suits, map to Rainbow.
+package hanablive
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Game represents a game exported from hanab.live.
+type Game struct {
+	// Players are the names of the players in the game
+	Players []string `json:"players"`
+
+	// Deck is the deck of cards in the order they are drawn
+	Deck []Card `json:"deck"`
+
+	// Actions are the actions taken by the players in order
+	Actions []Action `json:"actions"`
+
+	Options Options `json:"options"`
+}
+
+// Card represents a single card in the deck.
+type Card struct {
+	SuitIndex int `json:"suitIndex"`
+	Rank      int `json:"rank"`
+}
+
+// ActionType represents the type of an action.
+type ActionType int
+
+// The different types of actions
+const (
+	// ActionPlay plays the card with the order Target
+	ActionPlay ActionType = 0
+
+	// ActionDiscard discards the card with the order Target
+	ActionDiscard ActionType = 1
+
+	// ActionColorClue clues the player with index Target about the color with index Value
+	ActionColorClue ActionType = 2
+
+	// ActionRankClue clues the player with index Target about the rank Value
+	ActionRankClue ActionType = 3
+
+	// ActionGameOver ends the game prematurely
+	ActionGameOver ActionType = 4
+)
+
+// Action represents a single action taken by a player.
+//
+// Cards are referred to by their order, that is their index in the deck.
+type Action struct {
+	Type   ActionType `json:"type"`
+	Target int        `json:"target"`
+	Value  int        `json:"value"`
+}
+
+// Options represents the options of a game.
+type Options struct {
+	// Variant is the name of the variant of the game.
+	// When empty, "No Variant" is assumed.
+	Variant string `json:"variant,omitempty"`
+
+	// OneExtraCard and OneLessCard increase or decrease the size of each hand by one card
+	OneExtraCard bool `json:"oneExtraCard,omitempty"`
+	OneLessCard  bool `json:"oneLessCard,omitempty"`
+
+	// EmptyClues indicates if clues that do not touch any card may be given
+	EmptyClues bool `json:"emptyClues,omitempty"`
+}
+
+// Read reads a game in hanab.live format from r.
+func Read(r io.Reader) (*Game, error) {
+	var game Game
+	if err := json.NewDecoder(r).Decode(&game); err != nil {
+		return nil, errors.Wrap(err, "hanablive: Unable to read game")
+	}
+	return &game, nil
+}
+
+// Write writes this game in hanab.live format to w.
+func (game *Game) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(game)
+	return errors.Wrap(err, "hanablive: Unable to write game")
+}
+
+// variant represents a hanab.live variant supported by this package
+type variant struct {
+	Name string
+	Mode model.GameMode
+
+	// Suits are the colors of the suits in hanab.live order
+	Suits []model.CardColor
+
+	// ClueColors are the colors that can be clued in hanab.live order
+	ClueColors []model.CardColor
+}
+
+var fiveSuits = []model.CardColor{model.ColorRed, model.ColorYellow, model.ColorGreen, model.ColorBlue, model.ColorWhite}
+var sixSuits = append(fiveSuits[:5:5], model.ColorRainbow)
+
+// variants are the supported hanab.live variants
+var variants = []variant{
+	{Name: "No Variant", Mode: model.ModeFiveColor, Suits: fiveSuits, ClueColors: fiveSuits},
+	{Name: "6 Suits", Mode: model.ModeSixColor, Suits: sixSuits, ClueColors: sixSuits},
+	{Name: "Rainbow (6 Suits)", Mode: model.ModeRainbow, Suits: sixSuits, ClueColors: fiveSuits},
+	{Name: "Dark Rainbow (6 Suits)", Mode: model.ModeDarkRainbow, Suits: sixSuits, ClueColors: fiveSuits},
+}
+
+// ErrUnknownVariant is returned when a variant is not supported.
+var ErrUnknownVariant = errors.New("hanablive: Unsupported variant")
+
+// variantByName returns the variant with the provided hanab.live name.
+func variantByName(name string) (*variant, error) {
+	if name == "" {
+		name = variants[0].Name
+	}
+	for i := range variants {
+		if variants[i].Name == name {
+			return &variants[i], nil
+		}
+	}
+	return nil, ErrUnknownVariant
+}
+
+// variantByMode returns the variant corresponding to the provided GameMode.
+func variantByMode(mode model.GameMode) (*variant, error) {
+	for i := range variants {
+		if variants[i].Mode == mode {
+			return &variants[i], nil
+		}
+	}
+	return nil, ErrUnknownVariant
+}
+
+// indexOf returns the index of color in colors, or -1.
+func indexOf(colors []model.CardColor, color model.CardColor) int {
+	for i, c := range colors {
+		if c == color {
+			return i
+		}
+	}
+	return -1
+}
+
+// orders tracks the orders of the cards in the hands of the players.
+// It mirrors the way GameState manages hands.
+type orders struct {
+	hands    [][]int
+	next     int
+	deckSize int
+}
+
+// newOrders creates a new orders for a game that has just been started.
+func newOrders(state *model.GameState) *orders {
+	o := &orders{
+		hands:    make([][]int, len(state.Players)),
+		deckSize: len(state.Deck),
+	}
+	for i, p := range state.Players {
+		for range p.Hand {
+			o.draw(i)
+		}
+	}
+	return o
+}
+
+// draw draws the next card into the hand of player, if any.
+func (o *orders) draw(player int) {
+	if o.next >= o.deckSize {
+		return
+	}
+	o.hands[player] = append(o.hands[player], o.next)
+	o.next++
+}
+
+// take removes the card at index from the hand of player, and draws a new card.
+// It returns the order of the removed card.
+func (o *orders) take(player int, index int) int {
+	order := o.hands[player][index]
+	o.hands[player] = append(o.hands[player][:index], o.hands[player][index+1:]...)
+	o.draw(player)
+	return order
+}
+
+// find returns the index of the card with the provided order in the hand of player, or -1.
+func (o *orders) find(player int, order int) int {
+	for i, o := range o.hands[player] {
+		if o == order {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,301 @@
+// Package lobby provides Manager, which hosts many games of Hanabi at once.
+//
+// Each game is hosted at a table, identified by a random id.
+// Players join and leave tables before the game at the table has started.
+// Tables that have not been used for some time are removed by the garbage collector, see Manager.Collect.
+package lobby
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/actor"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Manager creates, lists and removes tables, and tracks the table each player is seated at.
+// All methods of Manager are goroutine safe.
+type Manager struct {
+	m       sync.Mutex
+	tables  map[uuid.UUID]*Table
+	players map[uuid.UUID]uuid.UUID // maps each player to the id of their table
+
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// Table is a single game hosted by a Manager.
+type Table struct {
+	ID      uuid.UUID
+	Game    *actor.Game
+	Created time.Time
+
+	// lastUsed is the last time the table was used, guarded by the mutex of the Manager
+	lastUsed time.Time
+}
+
+// Info describes a table as returned by Manager.List.
+type Info struct {
+	ID      uuid.UUID      `json:"id"`
+	Mode    model.GameMode `json:"mode"`
+	Created time.Time      `json:"created"`
+
+	// Players is the number of players seated at the table.
+	// MinPlayers and MaxPlayers are the number of players the game can be started with.
+	Players    int `json:"players"`
+	MinPlayers int `json:"minPlayers"`
+	MaxPlayers int `json:"maxPlayers"`
+
+	Started bool          `json:"started"`
+	Outcome model.Outcome `json:"outcome"`
+}
+
+// MinPlayers and MaxPlayers are the number of players a table created without explicit options can be started with.
+const (
+	MinPlayers = 2
+	MaxPlayers = 5
+)
+
+// DefaultOptions returns the rules of tables created without explicit options.
+// These are model.DefaultRuleOptions restricted to between MinPlayers and MaxPlayers players.
+// Six-player games are supported by the model, but have to be requested using explicit options.
+func DefaultOptions() *model.RuleOptions {
+	options := model.DefaultRuleOptions()
+	for players := range options.HandSizes {
+		if players < MinPlayers || players > MaxPlayers {
+			delete(options.HandSizes, players)
+		}
+	}
+	return options
+}
+
+// ErrTableNotFound is returned when a table does not exist.
+var ErrTableNotFound = errors.New("Manager: Table does not exist")
+
+// NewManager creates a new Manager without any tables.
+func NewManager() *Manager {
+	return &Manager{
+		tables:  make(map[uuid.UUID]*Table),
+		players: make(map[uuid.UUID]uuid.UUID),
+		now:     time.Now,
+	}
+}
+
+// Create creates a new table playing a game in mode.
+// When options is nil, DefaultOptions are used, otherwise a copy of options is used.
+//
+// When mode is not valid, returns model.ErrModeInvalid.
+// When options are not valid, returns model.ErrInvalidOptions.
+func (manager *Manager) Create(mode model.GameMode, options *model.RuleOptions) (*Table, error) {
+	if !mode.Valid() {
+		return nil, model.ErrModeInvalid
+	}
+	if options == nil {
+		options = DefaultOptions()
+	} else {
+		if !options.Valid() {
+			return nil, model.ErrInvalidOptions
+		}
+		options = options.Clone()
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "Manager: Unable to generate table id")
+	}
+
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	now := manager.now()
+	table := &Table{
+		ID:      id,
+		Game:    actor.New(&model.GameState{Mode: mode, Options: options}),
+		Created: now,
+
+		lastUsed: now,
+	}
+	manager.tables[id] = table
+	return table, nil
+}
+
+// Get returns the table with the provided id and marks it as used.
+// When no such table exists, returns ErrTableNotFound.
+func (manager *Manager) Get(id uuid.UUID) (*Table, error) {
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	table, ok := manager.tables[id]
+	if !ok {
+		return nil, ErrTableNotFound
+	}
+	table.lastUsed = manager.now()
+	return table, nil
+}
+
+// Touch marks the table with the provided id as used, so that it is not removed by Collect.
+// Users of a table that do not call Get for every access, such as long-lived connections, should call Touch instead.
+func (manager *Manager) Touch(id uuid.UUID) {
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	if table, ok := manager.tables[id]; ok {
+		table.lastUsed = manager.now()
+	}
+}
+
+// List returns information about all tables, ordered by the time they were created.
+func (manager *Manager) List(ctx context.Context) ([]Info, error) {
+	manager.m.Lock()
+	tables := make([]*Table, 0, len(manager.tables))
+	for _, table := range manager.tables {
+		tables = append(tables, table)
+	}
+	manager.m.Unlock()
+
+	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].Created.Equal(tables[j].Created) {
+			return tables[i].ID.String() < tables[j].ID.String()
+		}
+		return tables[i].Created.Before(tables[j].Created)
+	})
+
+	infos := make([]Info, 0, len(tables))
+	for _, table := range tables {
+		info := Info{ID: table.ID, Created: table.Created}
+		err := table.Game.Do(ctx, func(state *model.GameState) error {
+			info.Mode = state.Mode
+			info.Players = len(state.Players)
+			info.MinPlayers, info.MaxPlayers = state.Options.PlayerRange()
+			info.Started = state.Started
+			info.Outcome = state.Outcome
+			return nil
+		})
+		switch {
+		case err == actor.ErrClosed:
+			continue // collected in the meantime
+		case err != nil:
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Join seats a new player at the table with the provided id and returns their id, see GameState.AddPlayer.
+func (manager *Manager) Join(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	table, err := manager.Get(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	player, err := table.Game.AddPlayer(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	if _, ok := manager.tables[id]; !ok {
+		return uuid.Nil, ErrTableNotFound // collected in the meantime
+	}
+	manager.players[player] = id
+	return player, nil
+}
+
+// Leave removes player from the table with the provided id, see GameState.RemovePlayer.
+// Players can only leave before the game has started.
+// When player is not seated at the table, returns model.ErrUnknownPlayer.
+func (manager *Manager) Leave(ctx context.Context, id uuid.UUID, player uuid.UUID) error {
+	table, err := manager.Get(id)
+	if err != nil {
+		return err
+	}
+	if at, ok := manager.TableOf(player); !ok || at != id {
+		return model.ErrUnknownPlayer
+	}
+
+	if err := table.Game.RemovePlayer(ctx, player); err != nil {
+		return err
+	}
+
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	delete(manager.players, player)
+	return nil
+}
+
+// Start starts the game at the table with the provided id, see GameState.Start.
+// When the number of players seated is not supported by the options of the table, returns model.ErrInvalidPlayerCount.
+func (manager *Manager) Start(ctx context.Context, id uuid.UUID, seed int64) error {
+	table, err := manager.Get(id)
+	if err != nil {
+		return err
+	}
+	return table.Game.Do(ctx, func(state *model.GameState) error {
+		if state.Started {
+			return model.ErrGameStarted
+		}
+		if minPlayers, maxPlayers := state.Options.PlayerRange(); len(state.Players) < minPlayers || len(state.Players) > maxPlayers {
+			return model.ErrInvalidPlayerCount
+		}
+		return state.Start(seed)
+	})
+}
+
+// TableOf returns the id of the table player is seated at.
+// When player is not seated at any table, returns false.
+func (manager *Manager) TableOf(player uuid.UUID) (uuid.UUID, bool) {
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	id, ok := manager.players[player]
+	return id, ok
+}
+
+// Collect removes all tables that have not been used for at least idle, and returns their ids.
+// The games of removed tables are closed, and their players are no longer seated.
+func (manager *Manager) Collect(idle time.Duration) []uuid.UUID {
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	deadline := manager.now().Add(-idle)
+
+	var removed []uuid.UUID
+	for id, table := range manager.tables {
+		if table.lastUsed.After(deadline) {
+			continue
+		}
+		manager.remove(table)
+		removed = append(removed, id)
+	}
+	return removed
+}
+
+// Close removes all tables and closes their games.
+func (manager *Manager) Close() {
+	manager.m.Lock()
+	defer manager.m.Unlock()
+
+	for _, table := range manager.tables {
+		manager.remove(table)
+	}
+}
+
+// remove removes table and its players and closes its game.
+// The caller must hold the mutex of the manager.
+func (manager *Manager) remove(table *Table) {
+	table.Game.Close()
+	delete(manager.tables, table.ID)
+	for player, id := range manager.players {
+		if id == table.ID {
+			delete(manager.players, player)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package lobby
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// newTestManager creates a new Manager with a clock that only advances when told to.
+func newTestManager(t *testing.T) (*Manager, *time.Time) {
+	manager := NewManager()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	t.Cleanup(manager.Close)
+	return manager, &now
+}
+
+func TestManager_Create(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	tests := []struct {
+		name    string
+		mode    model.GameMode
+		options *model.RuleOptions
+		wantErr error
+	}{
+		{"default options", model.ModeFiveColor, nil, nil},
+		{"custom options", model.ModeRainbow, model.DefaultRuleOptions(), nil},
+		{"invalid mode", "invalid", nil, model.ErrModeInvalid},
+		{"invalid options", model.ModeFiveColor, &model.RuleOptions{}, model.ErrInvalidOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := manager.Create(tt.mode, tt.options)
+			if err != tt.wantErr {
+				t.Fatalf("Manager.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := manager.Get(table.ID)
+			if err != nil || got != table {
+				t.Errorf("Manager.Get() = %v, %v, want %v", got, err, table)
+			}
+		})
+	}
+
+	if _, err := manager.Get(uuid.New()); err != ErrTableNotFound {
+		t.Errorf("Manager.Get() error = %v, want %v", err, ErrTableNotFound)
+	}
+}
+
+func TestManager_Players(t *testing.T) {
+	manager, _ := newTestManager(t)
+	ctx := context.Background()
+
+	table, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, err := manager.Join(ctx, table.ID)
+	if err != nil {
+		t.Fatalf("Manager.Join() error = %v", err)
+	}
+	if at, ok := manager.TableOf(alice); !ok || at != table.ID {
+		t.Errorf("Manager.TableOf() = %v, %v, want %v, true", at, ok, table.ID)
+	}
+
+	// a single player can not start the game
+	if err := manager.Start(ctx, table.ID, 1); err != model.ErrInvalidPlayerCount {
+		t.Errorf("Manager.Start() error = %v, want %v", err, model.ErrInvalidPlayerCount)
+	}
+
+	bob, err := manager.Join(ctx, table.ID)
+	if err != nil {
+		t.Fatalf("Manager.Join() error = %v", err)
+	}
+
+	// players can only leave their own table
+	if err := manager.Leave(ctx, other.ID, bob); err != model.ErrUnknownPlayer {
+		t.Errorf("Manager.Leave() error = %v, want %v", err, model.ErrUnknownPlayer)
+	}
+	if err := manager.Leave(ctx, table.ID, bob); err != nil {
+		t.Errorf("Manager.Leave() error = %v", err)
+	}
+	if _, ok := manager.TableOf(bob); ok {
+		t.Error("Manager.TableOf() found player that left")
+	}
+	if err := manager.Leave(ctx, table.ID, bob); err != model.ErrUnknownPlayer {
+		t.Errorf("Manager.Leave() error = %v, want %v", err, model.ErrUnknownPlayer)
+	}
+
+	if _, err := manager.Join(ctx, table.ID); err != nil {
+		t.Fatalf("Manager.Join() error = %v", err)
+	}
+	if err := manager.Start(ctx, table.ID, 1); err != nil {
+		t.Fatalf("Manager.Start() error = %v", err)
+	}
+
+	// once started, nobody can join or leave
+	if _, err := manager.Join(ctx, table.ID); err != model.ErrGameStarted {
+		t.Errorf("Manager.Join() error = %v, want %v", err, model.ErrGameStarted)
+	}
+	if err := manager.Leave(ctx, table.ID, alice); err != model.ErrGameStarted {
+		t.Errorf("Manager.Leave() error = %v, want %v", err, model.ErrGameStarted)
+	}
+}
+
+func TestManager_Players_Max(t *testing.T) {
+	manager, _ := newTestManager(t)
+	ctx := context.Background()
+
+	options := model.DefaultRuleOptions()
+	options.HandSizes = map[int]int{2: 5, 3: 5}
+	table, err := manager.Create(model.ModeFiveColor, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := manager.Join(ctx, table.ID); err != nil {
+			t.Fatalf("Manager.Join() error = %v", err)
+		}
+	}
+	if _, err := manager.Join(ctx, table.ID); err != model.ErrInvalidPlayerCount {
+		t.Errorf("Manager.Join() error = %v, want %v", err, model.ErrInvalidPlayerCount)
+	}
+}
+
+func TestManager_Players_Default(t *testing.T) {
+	manager, _ := newTestManager(t)
+	ctx := context.Background()
+
+	// tables without options allow at most MaxPlayers players
+	table, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < MaxPlayers; i++ {
+		if _, err := manager.Join(ctx, table.ID); err != nil {
+			t.Fatalf("Manager.Join() error = %v", err)
+		}
+	}
+	if _, err := manager.Join(ctx, table.ID); err != model.ErrInvalidPlayerCount {
+		t.Errorf("Manager.Join() error = %v, want %v", err, model.ErrInvalidPlayerCount)
+	}
+
+	// six players need explicit options
+	six, err := manager.Create(model.ModeFiveColor, model.DefaultRuleOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		if _, err := manager.Join(ctx, six.ID); err != nil {
+			t.Fatalf("Manager.Join() error = %v", err)
+		}
+	}
+	if err := manager.Start(ctx, six.ID, 1); err != nil {
+		t.Errorf("Manager.Start() error = %v", err)
+	}
+}
+
+func TestManager_List(t *testing.T) {
+	manager, now := newTestManager(t)
+	ctx := context.Background()
+
+	first, err := manager.Create(model.ModeSixColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(time.Minute)
+	second, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Join(ctx, second.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := manager.List(ctx)
+	if err != nil {
+		t.Fatalf("Manager.List() error = %v", err)
+	}
+	want := []Info{
+		{ID: first.ID, Mode: model.ModeSixColor, Created: first.Created, Players: 0, MinPlayers: 2, MaxPlayers: 5},
+		{ID: second.ID, Mode: model.ModeFiveColor, Created: second.Created, Players: 1, MinPlayers: 2, MaxPlayers: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Manager.List() = %v, want %v", got, want)
+	}
+}
+
+func TestManager_Collect(t *testing.T) {
+	manager, now := newTestManager(t)
+	ctx := context.Background()
+
+	idle, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, err := manager.Join(ctx, idle.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	busy, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	touched, err := manager.Create(model.ModeFiveColor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	*now = now.Add(30 * time.Minute)
+	if _, err := manager.Get(busy.ID); err != nil {
+		t.Fatal(err)
+	}
+	manager.Touch(touched.ID)
+	*now = now.Add(30 * time.Minute)
+
+	if got, want := manager.Collect(time.Hour), []uuid.UUID{idle.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Manager.Collect() = %v, want %v", got, want)
+	}
+	if _, err := manager.Get(idle.ID); err != ErrTableNotFound {
+		t.Errorf("Manager.Get() error = %v, want %v", err, ErrTableNotFound)
+	}
+	if _, ok := manager.TableOf(player); ok {
+		t.Error("Manager.TableOf() found player of collected table")
+	}
+	for _, table := range []*Table{busy, touched} {
+		if _, err := manager.Get(table.ID); err != nil {
+			t.Errorf("Manager.Get() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ErrGameNotStarted is returned when a move is applied to a game that has not yet been started.
+var ErrGameNotStarted = errors.New("GameState: Game has not been started")
+
+// ErrNotYourTurn is returned when a move is made by a player other than the current player.
+var ErrNotYourTurn = errors.New("GameState: Move was not made by the current player")
+
+// ErrGameOver is returned when a move is applied to a game that has already ended.
+var ErrGameOver = errors.New("GameState: Game is already over")
+
+// ErrInvalidMoveKind is returned when a move has an unknown kind.
+var ErrInvalidMoveKind = errors.New("GameState: Unknown kind of move")
+
+// ErrInvalidIndex is returned when a move refers to a card outside of the hand of the player.
+var ErrInvalidIndex = errors.New("GameState: Index is not in the hand of the player")
+
+// ErrNoHints is returned when a hint is given, but no hints are available.
+var ErrNoHints = errors.New("GameState: No hints available")
+
+// ErrMaxHints is returned when a card is discarded, but the maximum number of hints is available.
+var ErrMaxHints = errors.New("GameState: Can not discard with the maximum number of hints available")
+
+// ErrIllegalHint is returned when a hint is not legal in the GameMode of the game.
+var ErrIllegalHint = errors.New("GameState: Hint is not legal in this GameMode")
+
+// ErrInvalidHintTarget is returned when a hint is given to an unknown player or to the current player.
+var ErrInvalidHintTarget = errors.New("GameState: Hint must be given to another player in the game")
+
+// ErrEmptyHint is returned when a hint does not touch any card in the hand of the hinted player.
+var ErrEmptyHint = errors.New("GameState: Hint does not touch any card")
+
+// Apply applies a move made by the current player to this game.
+//
+// When move.ID is uuid.Nil, the move is assumed to be made by the current player.
+// When the move is not valid in the current state, an error is returned and the state is not modified.
+//
+// A played card is put onto its color pile if it is the next card of that pile.
+// Completing a pile with a NumberFive returns a hint, unless the maximum number of hints are already available.
+// Otherwise the card is a misplay, and is put into the discard pile.
+// Discarding a card returns a hint to the players.
+// After a card has been played or discarded, the player draws a new card from the Stack (if any).
+// New cards are always appended to the end of the hand, so that lower indexes hold older cards.
+//
+// After the move, the turn passes to the next player and the end of the game is checked for.
+// Once the game is over, Apply returns ErrGameOver.
+//
+// The move is recorded in Moves, and the events it caused are appended to History.
+func (state *GameState) Apply(move Move) error {
+	if err := state.checkMove(&move); err != nil {
+		return err
+	}
+
+	player := state.Players[state.CurrentPlayer]
+	state.Moves = append(state.Moves, move)
+
+	switch move.Kind {
+	case MovePlay:
+		card := state.takeCard(player, move.Index)
+		if state.isPlayable(card) {
+			state.ColorPiles[card.Color] = card.Number
+			if card.Number == NumberFive && state.Hints < state.rules().MaxHints {
+				state.Hints++
+			}
+			state.emit(Event{Kind: EventPlay, Player: player.ID, Index: move.Index, Card: card})
+		} else {
+			state.Discarded = append(state.Discarded, card)
+			state.Misplays++
+			state.emit(Event{Kind: EventMisplay, Player: player.ID, Index: move.Index, Card: card})
+		}
+		state.drawCard(player)
+	case MoveDiscard:
+		card := state.takeCard(player, move.Index)
+		state.Discarded = append(state.Discarded, card)
+		state.Hints++
+		state.emit(Event{Kind: EventDiscard, Player: player.ID, Index: move.Index, Card: card})
+		state.drawCard(player)
+	case MoveHint:
+		state.Hints--
+		target := state.findPlayer(move.ToPlayerID)
+		touched := state.touchedBy(target, move.Hint)
+		state.learnHint(target, move.Hint)
+		state.emit(Event{Kind: EventHint, Player: player.ID, Hint: move.Hint, ToPlayerID: target.ID, Touched: touched})
+	}
+
+	state.CurrentPlayer = (state.CurrentPlayer + 1) % len(state.Players)
+	state.Turn++
+	state.checkEnd()
+
+	if state.Over() {
+		state.emitMetrics(Event{Kind: EventGameOver, Outcome: state.Outcome, Score: state.Score()})
+	} else {
+		state.emitMetrics(Event{Kind: EventTurn, Player: state.Players[state.CurrentPlayer].ID})
+	}
+	return nil
+}
+
+// checkMove checks that move can be applied to the current state.
+// When move.ID is uuid.Nil, updates it to the id of the current player.
+func (state *GameState) checkMove(move *Move) error {
+	if !state.Started {
+		return ErrGameNotStarted
+	}
+	if state.Over() {
+		return ErrGameOver
+	}
+
+	player := state.Players[state.CurrentPlayer]
+	if move.ID == uuid.Nil {
+		move.ID = player.ID
+	}
+	if move.ID != player.ID {
+		return ErrNotYourTurn
+	}
+
+	switch move.Kind {
+	case MovePlay:
+		if move.Index < 0 || move.Index >= len(player.Hand) {
+			return ErrInvalidIndex
+		}
+	case MoveDiscard:
+		if move.Index < 0 || move.Index >= len(player.Hand) {
+			return ErrInvalidIndex
+		}
+		if state.Hints >= state.rules().MaxHints {
+			return ErrMaxHints
+		}
+	case MoveHint:
+		if state.Hints == 0 {
+			return ErrNoHints
+		}
+		if !move.Hint.Legal(state.Mode) {
+			return ErrIllegalHint
+		}
+		target := state.findPlayer(move.ToPlayerID)
+		if target == nil || target == player {
+			return ErrInvalidHintTarget
+		}
+		if !state.rules().EmptyHints && len(state.touchedBy(target, move.Hint)) == 0 {
+			return ErrEmptyHint
+		}
+	default:
+		return ErrInvalidMoveKind
+	}
+
+	return nil
+}
+
+// findPlayer finds the player with the provided id.
+// If no such player exists, returns nil.
+func (state *GameState) findPlayer(id uuid.UUID) *Player {
+	for _, p := range state.Players {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// touchedBy returns the indexes of the cards in the hand of player that are touched by hint.
+func (state *GameState) touchedBy(player *Player, hint Hint) (touched []int) {
+	for i, c := range player.Hand {
+		if hint.Matches(c, state.Mode) {
+			touched = append(touched, i)
+		}
+	}
+	return touched
+}
+
+// isPlayable checks if card can be played onto its color pile.
+func (state *GameState) isPlayable(card Card) bool {
+	top, ok := state.ColorPiles[card.Color]
+	return ok && top+1 == card.Number
+}
+
+// learnHint updates the knowledge of player about their hand after being given hint.
+func (state *GameState) learnHint(player *Player, hint Hint) {
+	for i, c := range player.Hand {
+		player.Knowledge[i].Learn(hint, hint.Matches(c, state.Mode), state.Mode)
+	}
+}
+
+// takeCard removes the card at index from the hand of player and returns it.
+// The knowledge about the card is removed as well.
+func (state *GameState) takeCard(player *Player, index int) Card {
+	card := player.Hand[index]
+	player.Hand = append(player.Hand[:index], player.Hand[index+1:]...)
+	player.Knowledge = append(player.Knowledge[:index], player.Knowledge[index+1:]...)
+	return card
+}
+
+// drawCard draws the top card of the stack and appends it to the hand of player.
+// When the stack is empty, does nothing.
+func (state *GameState) drawCard(player *Player) {
+	top := len(state.Stack) - 1
+	if top < 0 {
+		return
+	}
+
+	card := state.Stack[top]
+	state.Stack = state.Stack[:top]
+
+	player.Hand = append(player.Hand, card)
+	player.Knowledge = append(player.Knowledge, NewKnowledge(state.Mode))
+	state.emit(Event{Kind: EventDraw, Player: player.ID, Index: len(player.Hand) - 1, Card: card})
+}
+
+// LegalMoves returns all moves that the current player can legally make.
+// Each returned move can be passed to Apply without an error, and has its ID set to the current player.
+//
+// Moves are returned in the following order:
+// first plays and then discards for each index in the hand of the player,
+// then hints for every other player in order, with color hints before number hints.
+//
+// When the game has not been started or is over, returns nil.
+func (state *GameState) LegalMoves() (moves []Move) {
+	if !state.Started || state.Over() {
+		return nil
+	}
+
+	player := state.Players[state.CurrentPlayer]
+
+	// collect all the candidate moves
+	candidates := make([]Move, 0, 2*len(player.Hand)+(len(state.Players)-1)*(len(validColors)+len(validNumbers)))
+	for i := range player.Hand {
+		candidates = append(candidates, Move{Kind: MovePlay, Index: i})
+	}
+	for i := range player.Hand {
+		candidates = append(candidates, Move{Kind: MoveDiscard, Index: i})
+	}
+	for _, p := range state.Players {
+		if p == player {
+			continue
+		}
+		for _, color := range validColors {
+			candidates = append(candidates, Move{Kind: MoveHint, Hint: color.Hint(), ToPlayerID: p.ID})
+		}
+		for _, number := range validNumbers {
+			candidates = append(candidates, Move{Kind: MoveHint, Hint: number.Hint(), ToPlayerID: p.ID})
+		}
+	}
+
+	// and keep only those that are legal
+	for _, move := range candidates {
+		if state.checkMove(&move) == nil {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
@@ -0,0 +1,225 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testPlayerIDs are fixed player ids used for testing
+var testPlayerIDs = []uuid.UUID{
+	uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+	uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+	uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+}
+
+// newTestState creates a new started FiveColor game with the provided hands and stack.
+// Player i receives the id testPlayerIDs[i].
+func newTestState(stack []Card, hands ...[]Card) *GameState {
+	state := &GameState{
+		Mode:      ModeFiveColor,
+		Stack:     append([]Card(nil), stack...),
+		Discarded: []Card{},
+		ColorPiles: map[CardColor]CardNumber{
+			ColorBlue:   NumberUnspecified,
+			ColorGreen:  NumberUnspecified,
+			ColorRed:    NumberUnspecified,
+			ColorWhite:  NumberUnspecified,
+			ColorYellow: NumberUnspecified,
+		},
+		Options: DefaultRuleOptions(),
+		Hints:   MaxHints,
+		Started: true,
+	}
+	for i, hand := range hands {
+		player := &Player{
+			ID:   testPlayerIDs[i],
+			Hand: append([]Card(nil), hand...),
+		}
+		for range hand {
+			player.Knowledge = append(player.Knowledge, NewKnowledge(state.Mode))
+		}
+		state.Players = append(state.Players, player)
+	}
+	return state
+}
+
+func TestGameState_Apply(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	b2 := Card{ColorBlue, NumberTwo}
+	r1 := Card{ColorRed, NumberOne}
+	r5 := Card{ColorRed, NumberFive}
+	g3 := Card{ColorGreen, NumberThree}
+
+	tests := []struct {
+		name    string
+		setup   func(state *GameState)
+		move    Move
+		wantErr error
+		check   func(t *testing.T, state *GameState)
+	}{
+		{
+			name: "playing a playable card",
+			move: Move{Kind: MovePlay, Index: 0},
+			check: func(t *testing.T, state *GameState) {
+				if state.ColorPiles[ColorBlue] != NumberOne {
+					t.Errorf("ColorPiles[Blue] = %v, want %v", state.ColorPiles[ColorBlue], NumberOne)
+				}
+				if want := []Card{b2, g3, r1}; !reflect.DeepEqual(state.Players[0].Hand, want) {
+					t.Errorf("Hand = %v, want %v", state.Players[0].Hand, want)
+				}
+				if len(state.Stack) != 1 {
+					t.Errorf("len(Stack) = %v, want 1", len(state.Stack))
+				}
+				if state.CurrentPlayer != 1 {
+					t.Errorf("CurrentPlayer = %v, want 1", state.CurrentPlayer)
+				}
+			},
+		},
+		{
+			name: "misplaying a card",
+			move: Move{Kind: MovePlay, Index: 1},
+			check: func(t *testing.T, state *GameState) {
+				if state.ColorPiles[ColorBlue] != NumberUnspecified {
+					t.Errorf("ColorPiles[Blue] = %v, want %v", state.ColorPiles[ColorBlue], NumberUnspecified)
+				}
+				if want := []Card{b2}; !reflect.DeepEqual(state.Discarded, want) {
+					t.Errorf("Discarded = %v, want %v", state.Discarded, want)
+				}
+				if state.Misplays != 1 {
+					t.Errorf("Misplays = %v, want 1", state.Misplays)
+				}
+			},
+		},
+		{
+			name: "playing a five returns a hint",
+			setup: func(state *GameState) {
+				state.ColorPiles[ColorRed] = NumberFour
+				state.Hints = 3
+				state.Players[0].Hand[0] = r5
+			},
+			move: Move{Kind: MovePlay, Index: 0},
+			check: func(t *testing.T, state *GameState) {
+				if state.Hints != 4 {
+					t.Errorf("Hints = %v, want 4", state.Hints)
+				}
+			},
+		},
+		{
+			name:  "discarding a card",
+			setup: func(state *GameState) { state.Hints = 3 },
+			move:  Move{Kind: MoveDiscard, Index: 2},
+			check: func(t *testing.T, state *GameState) {
+				if want := []Card{g3}; !reflect.DeepEqual(state.Discarded, want) {
+					t.Errorf("Discarded = %v, want %v", state.Discarded, want)
+				}
+				if want := []Card{b1, b2, r1}; !reflect.DeepEqual(state.Players[0].Hand, want) {
+					t.Errorf("Hand = %v, want %v", state.Players[0].Hand, want)
+				}
+				if state.Hints != 4 {
+					t.Errorf("Hints = %v, want 4", state.Hints)
+				}
+			},
+		},
+		{
+			name: "giving a hint",
+			move: Move{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: testPlayerIDs[1]},
+			check: func(t *testing.T, state *GameState) {
+				if state.Hints != MaxHints-1 {
+					t.Errorf("Hints = %v, want %v", state.Hints, MaxHints-1)
+				}
+				if len(state.Stack) != 2 {
+					t.Errorf("len(Stack) = %v, want 2", len(state.Stack))
+				}
+			},
+		},
+		{
+			name:  "drawing from an empty stack",
+			setup: func(state *GameState) { state.Stack = nil },
+			move:  Move{Kind: MovePlay, Index: 0},
+			check: func(t *testing.T, state *GameState) {
+				if len(state.Players[0].Hand) != 2 {
+					t.Errorf("len(Hand) = %v, want 2", len(state.Players[0].Hand))
+				}
+			},
+		},
+
+		{"not started", func(state *GameState) { state.Started = false }, Move{Kind: MovePlay}, ErrGameNotStarted, nil},
+		{"wrong player", nil, Move{Kind: MovePlay, ID: testPlayerIDs[1]}, ErrNotYourTurn, nil},
+		{"unknown kind", nil, Move{Kind: "pass"}, ErrInvalidMoveKind, nil},
+		{"play out of range", nil, Move{Kind: MovePlay, Index: 3}, ErrInvalidIndex, nil},
+		{"discard out of range", func(state *GameState) { state.Hints = 0 }, Move{Kind: MoveDiscard, Index: -1}, ErrInvalidIndex, nil},
+		{"discard at max hints", nil, Move{Kind: MoveDiscard, Index: 0}, ErrMaxHints, nil},
+		{"hint without hints", func(state *GameState) { state.Hints = 0 }, Move{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: testPlayerIDs[1]}, ErrNoHints, nil},
+		{"illegal hint", nil, Move{Kind: MoveHint, Hint: Hint(b1), ToPlayerID: testPlayerIDs[1]}, ErrIllegalHint, nil},
+		{"hint to self", nil, Move{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: testPlayerIDs[0]}, ErrInvalidHintTarget, nil},
+		{"hint to unknown player", nil, Move{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: testPlayerIDs[2]}, ErrInvalidHintTarget, nil},
+		{"hint touching no cards", nil, Move{Kind: MoveHint, Hint: ColorYellow.Hint(), ToPlayerID: testPlayerIDs[1]}, ErrEmptyHint, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState([]Card{g3, r1}, []Card{b1, b2, g3}, []Card{r1, b1, g3})
+			if tt.setup != nil {
+				tt.setup(state)
+			}
+
+			err := state.Apply(tt.move)
+			if err != tt.wantErr {
+				t.Errorf("GameState.Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, state)
+			}
+		})
+	}
+}
+
+func TestGameState_LegalMoves(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+
+	id0 := testPlayerIDs[0]
+	id1 := testPlayerIDs[1]
+
+	tests := []struct {
+		name  string
+		hints uint8
+		want  []Move
+	}{
+		{"max hints", MaxHints, []Move{
+			{Kind: MovePlay, ID: id0, Index: 0},
+			{Kind: MovePlay, ID: id0, Index: 1},
+			{Kind: MoveHint, ID: id0, Hint: ColorBlue.Hint(), ToPlayerID: id1},
+			{Kind: MoveHint, ID: id0, Hint: ColorRed.Hint(), ToPlayerID: id1},
+			{Kind: MoveHint, ID: id0, Hint: NumberOne.Hint(), ToPlayerID: id1},
+			{Kind: MoveHint, ID: id0, Hint: NumberTwo.Hint(), ToPlayerID: id1},
+		}},
+		{"some hints", 3, []Move{
+			{Kind: MovePlay, ID: id0, Index: 0},
+			{Kind: MovePlay, ID: id0, Index: 1},
+			{Kind: MoveDiscard, ID: id0, Index: 0},
+			{Kind: MoveDiscard, ID: id0, Index: 1},
+			{Kind: MoveHint, ID: id0, Hint: ColorBlue.Hint(), ToPlayerID: id1},
+			{Kind: MoveHint, ID: id0, Hint: ColorRed.Hint(), ToPlayerID: id1},
+			{Kind: MoveHint, ID: id0, Hint: NumberOne.Hint(), ToPlayerID: id1},
+			{Kind: MoveHint, ID: id0, Hint: NumberTwo.Hint(), ToPlayerID: id1},
+		}},
+		{"no hints", 0, []Move{
+			{Kind: MovePlay, ID: id0, Index: 0},
+			{Kind: MovePlay, ID: id0, Index: 1},
+			{Kind: MoveDiscard, ID: id0, Index: 0},
+			{Kind: MoveDiscard, ID: id0, Index: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState(nil, []Card{r2, r2}, []Card{b1, r2})
+			state.Hints = tt.hints
+
+			if got := state.LegalMoves(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GameState.LegalMoves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -32,26 +32,32 @@ func (c Card) Legal(mode GameMode) bool {
 // Each of the colors are hit in the order Blue,Green,Red,White,Yellow,Rainbow
 // Within each color, cards are hit in ascending order.
 func ForEachValidCard(f func(Card)) {
-	for _, color := range []CardColor{
-		ColorBlue,
-		ColorGreen,
-		ColorRed,
-		ColorWhite,
-		ColorYellow,
-		ColorRainbow,
-	} {
-		for _, number := range []CardNumber{
-			NumberOne,
-			NumberTwo,
-			NumberThree,
-			NumberFour,
-			NumberFive,
-		} {
+	for _, color := range validColors {
+		for _, number := range validNumbers {
 			f(Card{Color: color, Number: number})
 		}
 	}
 }
 
+// validColors holds all valid colors in the order used by ForEachValidCard.
+var validColors = []CardColor{
+	ColorBlue,
+	ColorGreen,
+	ColorRed,
+	ColorWhite,
+	ColorYellow,
+	ColorRainbow,
+}
+
+// validNumbers holds all valid numbers in ascending order.
+var validNumbers = []CardNumber{
+	NumberOne,
+	NumberTwo,
+	NumberThree,
+	NumberFour,
+	NumberFive,
+}
+
 // Hint represents a Hint on a set of cards.
 //
 // A Hint uses the same struct as a card, except that it expects
@@ -79,19 +85,7 @@ func (h Hint) String() string {
 // Legal checks if a hint is legal in a given GameMode.
 // This assumes that the GameMode is valid, and may panic if not.
 func (h Hint) Legal(mode GameMode) bool {
-	// Valid hints are legal as follows:
-	// - Number hints are legal in every mode.
-	// - Non-rainbow color hints are legal in every mode
-	// - Rainbow color hints are legal only in SixColor mode
-	//
-	// For valid hints, this compresses down to a single 'or' condition.
-	// This works because for number hints h.Color == ColorUnspecified, that means h.Color != ColorRainbow will be true.
-
-	if !h.Valid() {
-		return false
-	}
-
-	return h.Color != ColorRainbow || mode == ModeSixColor
+	return mode.mustVariant("hint.Legal()").HintLegal(h)
 }
 
 // IsNumberHint checks if this hint represents a valid number hint.
@@ -107,18 +101,7 @@ func (h Hint) IsColorHint() bool {
 // Matches checks if a hint matches a card in this GameMode.
 // Assumes that h.Legal(mode) and c.Legal() are true.
 func (h Hint) Matches(c Card, mode GameMode) bool {
-	// TODO: This function is not yet tested.
-
-	// - number hints match all cards of that number
-	// - in non-Rainbow + non-DarkRainbow color hints match all cards of that color
-	// - in Rainbow + non-DarkRainbow, color hints match the color and rainbow cards
-
-	if (mode == ModeRainbow || mode == ModeDarkRainbow) && h.IsColorHint() {
-		return h.Color == c.Color || c.Color == ColorRainbow
-	}
-
-	// regular color matching
-	return h.Number == c.Number || h.Color == c.Color
+	return mode.mustVariant("hint.Matches()").Touches(h, c)
 }
 
 // CardColor represents the color of a card in hanabi
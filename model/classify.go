@@ -0,0 +1,119 @@
+package model
+
+// CardClass represents how useful a card is for the rest of the game.
+type CardClass string
+
+// The different classes of cards.
+// When more than one class applies to a card, the first applicable class in this list is used.
+const (
+	// ClassTrash indicates that a card can never be played.
+	// Either the card has already been played, or all copies of a lower card of the same color have been discarded.
+	ClassTrash CardClass = "trash"
+
+	// ClassPlayable indicates that a card can be played right now.
+	ClassPlayable CardClass = "playable"
+
+	// ClassCritical indicates that a card is the last copy that has not been discarded, and is not playable right now.
+	// Discarding it lowers the maximum score of the game.
+	// Playable cards may be critical as well, use IsCritical to check for this.
+	ClassCritical CardClass = "critical"
+
+	// ClassUseful indicates that a card can be played in the future, and another copy of it exists.
+	ClassUseful CardClass = "useful"
+)
+
+// Classify returns the CardClass of card in this game.
+// This function assumes that card is legal in the mode of the game.
+func (state *GameState) Classify(card Card) CardClass {
+	return classify(card, state.ColorPiles, state.Discarded, state.Mode)
+}
+
+// Classify returns the CardClass of card, see GameState.Classify.
+func (view *View) Classify(card Card) CardClass {
+	return classify(card, view.ColorPiles, view.Discarded, view.Mode)
+}
+
+// IsCritical checks if card is critical in this game, that is discarding it lowers the maximum score.
+// Unlike Classify, this includes playable cards.
+// This function assumes that card is legal in the mode of the game.
+func (state *GameState) IsCritical(card Card) bool {
+	return isCritical(card, state.ColorPiles, state.Discarded, state.Mode)
+}
+
+// IsCritical checks if card is critical, see GameState.IsCritical.
+func (view *View) IsCritical(card Card) bool {
+	return isCritical(card, view.ColorPiles, view.Discarded, view.Mode)
+}
+
+// MaxScore returns the maximum score that can still be achieved in this game.
+//
+// A color pile can not be completed once all copies of one of its missing cards have been discarded.
+// When the game is over, this is the same as Score.
+func (state *GameState) MaxScore() int {
+	if state.Over() {
+		return state.Score()
+	}
+	return maxScore(state.ColorPiles, state.Discarded, state.Mode)
+}
+
+// MaxScore returns the maximum score that can still be achieved in the game, see GameState.MaxScore.
+func (view *View) MaxScore() int {
+	if view.Outcome != OutcomeNone {
+		return view.Score()
+	}
+	return maxScore(view.ColorPiles, view.Discarded, view.Mode)
+}
+
+// classify implements GameState.Classify and View.Classify.
+func classify(card Card, piles map[CardColor]CardNumber, discarded []Card, mode GameMode) CardClass {
+	if card.Number <= piles[card.Color] || card.Number > reachable(card.Color, piles, discarded, mode) {
+		return ClassTrash
+	}
+	if piles[card.Color]+1 == card.Number {
+		return ClassPlayable
+	}
+	if countCard(discarded, card) >= mode.Count(card)-1 {
+		return ClassCritical
+	}
+	return ClassUseful
+}
+
+// isCritical implements GameState.IsCritical and View.IsCritical.
+func isCritical(card Card, piles map[CardColor]CardNumber, discarded []Card, mode GameMode) bool {
+	if classify(card, piles, discarded, mode) == ClassTrash {
+		return false
+	}
+	return countCard(discarded, card) >= mode.Count(card)-1
+}
+
+// maxScore implements GameState.MaxScore and View.MaxScore.
+func maxScore(piles map[CardColor]CardNumber, discarded []Card, mode GameMode) (score int) {
+	for _, color := range mode.Suits() {
+		score += int(reachable(color, piles, discarded, mode))
+	}
+	return score
+}
+
+// reachable returns the highest number the pile of color can reach.
+// This is the number below the lowest missing card of which all copies have been discarded.
+func reachable(color CardColor, piles map[CardColor]CardNumber, discarded []Card, mode GameMode) CardNumber {
+	number := piles[color]
+	for number < NumberFive {
+		next := Card{Color: color, Number: number + 1}
+		if countCard(discarded, next) >= mode.Count(next) {
+			break
+		}
+		number++
+	}
+	return number
+}
+
+// countCard counts how often card occurs in cards.
+func countCard(cards []Card, card Card) (count int) {
+	for _, c := range cards {
+		if c == card {
+			count++
+		}
+	}
+	return count
+}
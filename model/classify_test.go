@@ -0,0 +1,144 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestGameState_Classify(t *testing.T) {
+	r1 := Card{ColorRed, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+	r3 := Card{ColorRed, NumberThree}
+	r4 := Card{ColorRed, NumberFour}
+	b2 := Card{ColorBlue, NumberTwo}
+	b3 := Card{ColorBlue, NumberThree}
+	b5 := Card{ColorBlue, NumberFive}
+	g1 := Card{ColorGreen, NumberOne}
+
+	state := newTestState(nil, []Card{r1}, []Card{r1})
+	state.ColorPiles[ColorRed] = NumberOne
+	state.ColorPiles[ColorBlue] = NumberOne
+	state.Discarded = []Card{r3, r3, b3}
+
+	tests := []struct {
+		card Card
+		want CardClass
+	}{
+		{r1, ClassTrash},
+		{r2, ClassPlayable},
+		{r4, ClassTrash},
+		{b2, ClassPlayable},
+		{b3, ClassCritical},
+		{b5, ClassCritical},
+		{g1, ClassPlayable},
+		{Card{ColorGreen, NumberTwo}, ClassUseful},
+	}
+	for _, tt := range tests {
+		t.Run(tt.card.String(), func(t *testing.T) {
+			if got := state.Classify(tt.card); got != tt.want {
+				t.Errorf("GameState.Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	view, err := state.PlayerView(testPlayerIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := view.Classify(b3); got != ClassCritical {
+		t.Errorf("View.Classify() = %v, want %v", got, ClassCritical)
+	}
+}
+
+func TestGameState_IsCritical(t *testing.T) {
+	r1 := Card{ColorRed, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+	r3 := Card{ColorRed, NumberThree}
+	b2 := Card{ColorBlue, NumberTwo}
+	b3 := Card{ColorBlue, NumberThree}
+	b4 := Card{ColorBlue, NumberFour}
+	b5 := Card{ColorBlue, NumberFive}
+	y5 := Card{ColorYellow, NumberFive}
+
+	state := newTestState(nil, []Card{r1}, []Card{r1})
+	state.ColorPiles[ColorRed] = NumberOne
+	state.ColorPiles[ColorBlue] = NumberFour
+	state.Discarded = []Card{r2, b3, b3}
+
+	tests := []struct {
+		card Card
+		want bool
+	}{
+		{b5, true},  // playable five
+		{r2, true},  // playable last copy
+		{y5, true},  // five that is not playable
+		{r3, false}, // two copies left
+		{r1, false}, // already played
+		{b2, false}, // already played
+		{b4, false}, // already played
+	}
+	for _, tt := range tests {
+		t.Run(tt.card.String(), func(t *testing.T) {
+			if got := state.IsCritical(tt.card); got != tt.want {
+				t.Errorf("GameState.IsCritical() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// critical playable cards are still classified as playable
+	if got := state.Classify(b5); got != ClassPlayable {
+		t.Errorf("GameState.Classify() = %v, want %v", got, ClassPlayable)
+	}
+
+	view, err := state.PlayerView(testPlayerIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !view.IsCritical(r2) {
+		t.Error("View.IsCritical() = false, want true")
+	}
+}
+
+func TestGameState_MaxScore(t *testing.T) {
+	r3 := Card{ColorRed, NumberThree}
+	b5 := Card{ColorBlue, NumberFive}
+	g1 := Card{ColorGreen, NumberOne}
+
+	tests := []struct {
+		name      string
+		discarded []Card
+		want      int
+	}{
+		{"nothing discarded", nil, 25},
+		{"one copy discarded", []Card{r3}, 25},
+		{"both threes discarded", []Card{r3, r3}, 22},
+		{"five discarded", []Card{b5}, 24},
+		{"all ones discarded", []Card{g1, g1, g1}, 20},
+		{"multiple colors", []Card{r3, r3, b5, g1, g1, g1}, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState(nil, []Card{g1}, []Card{g1})
+			state.Discarded = tt.discarded
+			if got := state.MaxScore(); got != tt.want {
+				t.Errorf("GameState.MaxScore() = %v, want %v", got, tt.want)
+			}
+
+			view, err := state.PlayerView(testPlayerIDs[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := view.MaxScore(); got != tt.want {
+				t.Errorf("View.MaxScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("game over", func(t *testing.T) {
+		state := newTestState(nil, []Card{g1}, []Card{g1})
+		state.ColorPiles[ColorRed] = NumberTwo
+		state.Outcome = OutcomeDeckout
+		if got := state.MaxScore(); got != 2 {
+			t.Errorf("GameState.MaxScore() = %v, want 2", got)
+		}
+	})
+}
@@ -1,3 +1,6 @@
 // Package model contains agent-independent structures that represent the Hanabi Game.
 // In this package all structures are all-knowning, that is they do not have any hidden game state.
+//
+// The only exception to this is View, which represents a game from the perspective of a single player.
+// It can be obtained using GameState.PlayerView and is safe to send to players.
 package model
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// EventKind represents the kind of an event that occured in a game.
+type EventKind string
+
+// The different kinds of events
+const (
+	// EventDraw indicates that Player drew Card from the stack and put it into their hand at Index.
+	EventDraw EventKind = "draw"
+
+	// EventPlay indicates that Player successfully played Card from Index in their hand.
+	EventPlay EventKind = "play"
+
+	// EventMisplay indicates that Player unsuccessfully played Card from Index in their hand.
+	EventMisplay EventKind = "misplay"
+
+	// EventDiscard indicates that Player discarded Card from Index in their hand.
+	EventDiscard EventKind = "discard"
+
+	// EventHint indicates that Player gave Hint to ToPlayerID, touching the cards at the indexes Touched.
+	EventHint EventKind = "hint"
+
+	// EventTurn indicates that it is now the turn of Player, and records the current Metrics.
+	EventTurn EventKind = "turn"
+
+	// EventGameOver indicates that the game ended with Outcome and Score, and records the final Metrics.
+	EventGameOver EventKind = "game-over"
+)
+
+// Event represents something that happened in a game.
+// Only the fields relevant to the Kind of event are set, see the documentation of the different EventKinds.
+type Event struct {
+	Kind EventKind `json:"kind"`
+
+	// Turn is the value of GameState.Turn when the event happened.
+	Turn int `json:"turn"`
+
+	Player uuid.UUID `json:"player"`
+	Index  int       `json:"index"`
+	Card   Card      `json:"card"`
+
+	Hint       Hint      `json:"hint"`
+	ToPlayerID uuid.UUID `json:"toPlayerId"`
+	Touched    []int     `json:"touched,omitempty"`
+
+	Outcome Outcome `json:"outcome,omitempty"`
+	Score   int     `json:"score,omitempty"`
+
+	Metrics *Metrics `json:"metrics,omitempty"`
+}
+
+// Redact returns a copy of this event as seen by viewer.
+// This removes the cards drawn by viewer, as they can not see them.
+// Metrics only depend on public information, and are kept.
+func (e Event) Redact(viewer uuid.UUID) Event {
+	if e.Kind == EventDraw && e.Player == viewer {
+		e.Card = Card{}
+	}
+	return e
+}
+
+// PlayerHistory returns the History of this game as seen by the player with the provided id.
+// When no such player exists, returns ErrUnknownPlayer.
+func (state *GameState) PlayerHistory(id uuid.UUID) ([]Event, error) {
+	if state.findPlayer(id) == nil {
+		return nil, ErrUnknownPlayer
+	}
+
+	history := make([]Event, len(state.History))
+	for i, e := range state.History {
+		history[i] = e.Redact(id)
+	}
+	return history, nil
+}
+
+// emit appends an event that happened in the current turn to the history.
+func (state *GameState) emit(e Event) {
+	e.Turn = state.Turn
+	state.History = append(state.History, e)
+}
+
+// ErrNoSeed is returned when replaying a game that was not started with a seed, see Replay.
+var ErrNoSeed = errors.New("Replay: Game has no seed")
+
+// Replay re-creates a game from the seed passed to Start, the ids of the players and the list of moves made.
+// The game is played with the provided options, or with the DefaultRuleOptions when options is nil.
+//
+// The returned game is identical to the game the moves were originally made in.
+// The seed and options should be the Seed and Options of the original game.
+// Games started with StartWithDeck have a Seed of 0 and can not be replayed, use Branch instead.
+// When seed is 0, returns ErrNoSeed.
+// When a move can not be applied, returns an error.
+func Replay(seed int64, players []uuid.UUID, mode GameMode, options *RuleOptions, moves []Move) (*GameState, error) {
+	if seed == 0 {
+		return nil, ErrNoSeed
+	}
+
+	state := &GameState{Mode: mode}
+	if options != nil {
+		state.Options = options.Clone()
+	}
+	for _, id := range players {
+		state.Players = append(state.Players, &Player{ID: id})
+	}
+
+	if err := state.Start(seed); err != nil {
+		return nil, errors.Wrap(err, "Replay: Unable to start game")
+	}
+
+	for i, move := range moves {
+		if err := state.Apply(move); err != nil {
+			return nil, errors.Wrapf(err, "Replay: Unable to apply move %d", i)
+		}
+	}
+
+	return state, nil
+}
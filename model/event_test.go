@@ -0,0 +1,139 @@
+package model
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// playRandomGame starts a new game with the provided players and seed.
+// It then makes random legal moves until the game is over.
+func playRandomGame(t *testing.T, mode GameMode, players int, seed int64) *GameState {
+	state := &GameState{Mode: mode}
+	for i := 0; i < players; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatalf("GameState.AddPlayer() error = %v", err)
+		}
+	}
+	if err := state.Start(seed); err != nil {
+		t.Fatalf("GameState.Start() error = %v", err)
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	for !state.Over() {
+		moves := state.LegalMoves()
+		if err := state.Apply(moves[random.Intn(len(moves))]); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+	}
+	return state
+}
+
+func TestGameState_History(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	r1 := Card{ColorRed, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+
+	id0 := testPlayerIDs[0]
+	id1 := testPlayerIDs[1]
+
+	state := newTestState([]Card{r2}, []Card{b1, r2}, []Card{r1, b1})
+	moves := []Move{
+		{Kind: MovePlay, Index: 1},
+		{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: id0},
+	}
+	for _, move := range moves {
+		if err := state.Apply(move); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+	}
+
+	wantMoves := []Move{
+		{Kind: MovePlay, ID: id0, Index: 1},
+		{Kind: MoveHint, ID: id1, Hint: NumberOne.Hint(), ToPlayerID: id0},
+	}
+	if !reflect.DeepEqual(state.Moves, wantMoves) {
+		t.Errorf("GameState.Moves = %v, want %v", state.Moves, wantMoves)
+	}
+
+	wantHistory := []Event{
+		{Kind: EventMisplay, Turn: 0, Player: id0, Index: 1, Card: r2},
+		{Kind: EventDraw, Turn: 0, Player: id0, Index: 1, Card: r2},
+		{Kind: EventTurn, Turn: 1, Player: id1, Metrics: &Metrics{Pace: -23, CardsNeeded: 25, FutureClues: 8}},
+		{Kind: EventHint, Turn: 1, Player: id1, Hint: NumberOne.Hint(), ToPlayerID: id0, Touched: []int{0}},
+		{Kind: EventTurn, Turn: 2, Player: id0, Metrics: &Metrics{Pace: -24, CardsGotten: 1, CluesSpent: 1, CardsNeeded: 24, FutureClues: 7}},
+	}
+	if !reflect.DeepEqual(state.History, wantHistory) {
+		t.Errorf("GameState.History = %v, want %v", state.History, wantHistory)
+	}
+
+	history, err := state.PlayerHistory(id0)
+	if err != nil {
+		t.Fatalf("GameState.PlayerHistory() error = %v", err)
+	}
+	if history[1].Card != (Card{}) {
+		t.Errorf("GameState.PlayerHistory() contains own drawn card %v", history[1].Card)
+	}
+	if history[0].Card != r2 {
+		t.Errorf("GameState.PlayerHistory() does not contain misplayed card")
+	}
+
+	if _, err := state.PlayerHistory(uuid.Nil); err != ErrUnknownPlayer {
+		t.Errorf("GameState.PlayerHistory() error = %v, want %v", err, ErrUnknownPlayer)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	for _, mode := range []GameMode{ModeFiveColor, ModeSixColor, ModeRainbow, ModeDarkRainbow} {
+		for players := 2; players <= 5; players++ {
+			original := playRandomGame(t, mode, players, int64(players))
+
+			ids := make([]uuid.UUID, len(original.Players))
+			for i, p := range original.Players {
+				ids[i] = p.ID
+			}
+
+			replayed, err := Replay(original.Seed, ids, original.Mode, original.Options, original.Moves)
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if !reflect.DeepEqual(replayed, original) {
+				t.Errorf("Replay() for %v with %d players did not reproduce the original game", mode, players)
+			}
+		}
+	}
+
+	// a game with non-default options
+	options := DefaultRuleOptions()
+	options.OneExtraCard = true
+	options.MaxHints = 5
+	original := &GameState{Mode: ModeFiveColor, Options: options, Players: []*Player{{ID: testPlayerIDs[0]}, {ID: testPlayerIDs[1]}}}
+	if err := original.Start(42); err != nil {
+		t.Fatalf("GameState.Start() error = %v", err)
+	}
+	random := rand.New(rand.NewSource(42))
+	for !original.Over() {
+		moves := original.LegalMoves()
+		if err := original.Apply(moves[random.Intn(len(moves))]); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+	}
+	replayed, err := Replay(original.Seed, testPlayerIDs[:2], original.Mode, original.Options, original.Moves)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if !reflect.DeepEqual(replayed, original) {
+		t.Errorf("Replay() with options did not reproduce the original game")
+	}
+
+	// a game started from a deck has no seed to replay it from
+	state := &GameState{Mode: ModeFiveColor, Players: []*Player{{ID: testPlayerIDs[0]}, {ID: testPlayerIDs[1]}}}
+	if err := state.StartWithDeck(ModeFiveColor.NewStack()); err != nil {
+		t.Fatalf("GameState.StartWithDeck() error = %v", err)
+	}
+	if _, err := Replay(state.Seed, testPlayerIDs[:2], state.Mode, nil, nil); err != ErrNoSeed {
+		t.Errorf("Replay() error = %v, want %v", err, ErrNoSeed)
+	}
+}
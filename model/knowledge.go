@@ -0,0 +1,131 @@
+package model
+
+import "math/bits"
+
+// CardSet represents a set of valid cards.
+//
+// Each valid card is represented by a single bit.
+// The bit of a card is 5*c + (n-1), where c is the index of its color in the order of ForEachValidCard and n its number.
+// The zero value is the empty set.
+type CardSet uint32
+
+// NewCardSet returns a new set containing the provided cards.
+// Invalid cards are ignored.
+func NewCardSet(cards ...Card) (set CardSet) {
+	for _, c := range cards {
+		set = set.Add(c)
+	}
+	return set
+}
+
+// cardBit returns the bit representing the card c in a CardSet.
+// When c is not valid, returns 0.
+func cardBit(c Card) CardSet {
+	if !c.Valid() {
+		return 0
+	}
+	for i, color := range validColors {
+		if color == c.Color {
+			return 1 << (uint(i)*uint(len(validNumbers)) + uint(c.Number-NumberOne))
+		}
+	}
+	return 0
+}
+
+// Contains checks if the set contains the card c.
+func (set CardSet) Contains(c Card) bool {
+	bit := cardBit(c)
+	return bit != 0 && set&bit == bit
+}
+
+// Add returns a new set that additionally contains c.
+func (set CardSet) Add(c Card) CardSet {
+	return set | cardBit(c)
+}
+
+// Remove returns a new set that does not contain c.
+func (set CardSet) Remove(c Card) CardSet {
+	return set &^ cardBit(c)
+}
+
+// Len returns the number of cards in this set.
+func (set CardSet) Len() int {
+	return bits.OnesCount32(uint32(set))
+}
+
+// Cards returns the cards in this set.
+// The order of the returned cards is the same as in ForEachValidCard.
+func (set CardSet) Cards() (cards []Card) {
+	ForEachValidCard(func(c Card) {
+		if set.Contains(c) {
+			cards = append(cards, c)
+		}
+	})
+	return cards
+}
+
+func (set CardSet) String() string {
+	s := "{"
+	for i, c := range set.Cards() {
+		if i != 0 {
+			s += ", "
+		}
+		s += c.String()
+	}
+	return s + "}"
+}
+
+// CardSet returns the set of cards that are legal in this GameMode.
+// This function assumes that mode is valid.
+func (mode GameMode) CardSet() (set CardSet) {
+	ForEachValidCard(func(c Card) {
+		if c.Legal(mode) {
+			set = set.Add(c)
+		}
+	})
+	return set
+}
+
+// Knowledge represents what the owner of a card in a hand knows about it.
+//
+// Knowledge only records information obtained from hints.
+// It does not take into account other cards visible to the player.
+type Knowledge struct {
+	// Possible is the set of cards the card may still be
+	Possible CardSet `json:"possible"`
+
+	// Clued indicates if the card has been touched by at least one hint
+	Clued bool `json:"clued"`
+}
+
+// NewKnowledge returns the Knowledge about a newly drawn card in a given GameMode.
+// This function assumes that mode is valid.
+func NewKnowledge(mode GameMode) Knowledge {
+	return Knowledge{Possible: mode.CardSet()}
+}
+
+// Known returns the card if its identity is known.
+// When more than one card is possible, returns false.
+func (k Knowledge) Known() (Card, bool) {
+	if k.Possible.Len() != 1 {
+		return Card{}, false
+	}
+	return k.Possible.Cards()[0], true
+}
+
+// Learn updates this knowledge with the result of a hint given in the provided GameMode.
+// Touched indicates if the card was touched by the hint.
+//
+// A card touched by the hint can only be a card that the hint matches.
+// Conversely, a card not touched by the hint can not be any card that the hint matches.
+// This function assumes that hint is legal in mode.
+func (k *Knowledge) Learn(hint Hint, touched bool, mode GameMode) {
+	for _, c := range k.Possible.Cards() {
+		if hint.Matches(c, mode) != touched {
+			k.Possible = k.Possible.Remove(c)
+		}
+	}
+	if touched {
+		k.Clued = true
+	}
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardSet(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	m5 := Card{ColorRainbow, NumberFive}
+
+	set := NewCardSet(m5, b1, Card{ColorUnspecified, NumberOne})
+	if set.Len() != 2 {
+		t.Errorf("CardSet.Len() = %v, want 2", set.Len())
+	}
+	if !set.Contains(b1) || !set.Contains(m5) {
+		t.Errorf("CardSet.Contains() = false, want true")
+	}
+	if want := []Card{b1, m5}; !reflect.DeepEqual(set.Cards(), want) {
+		t.Errorf("CardSet.Cards() = %v, want %v", set.Cards(), want)
+	}
+	if set = set.Remove(b1); set.Contains(b1) {
+		t.Errorf("CardSet.Remove() did not remove card")
+	}
+}
+
+func TestGameMode_CardSet(t *testing.T) {
+	tests := []struct {
+		name string
+		mode GameMode
+		want int
+	}{
+		{"FiveColor", ModeFiveColor, 25},
+		{"SixColor", ModeSixColor, 30},
+		{"Rainbow", ModeRainbow, 30},
+		{"DarkRainbow", ModeDarkRainbow, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mode.CardSet().Len(); got != tt.want {
+				t.Errorf("GameMode.CardSet().Len() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKnowledge_Learn(t *testing.T) {
+	type args struct {
+		hint    Hint
+		touched bool
+	}
+	tests := []struct {
+		name string
+		mode GameMode
+		args []args
+		want int
+	}{
+		{"touched by number hint", ModeFiveColor, []args{{NumberOne.Hint(), true}}, 5},
+		{"not touched by number hint", ModeFiveColor, []args{{NumberOne.Hint(), false}}, 20},
+		{"touched by color hint", ModeFiveColor, []args{{ColorRed.Hint(), true}}, 5},
+		{"not touched by color hint", ModeFiveColor, []args{{ColorRed.Hint(), false}}, 20},
+		{"touched by color and number hint", ModeFiveColor, []args{{ColorRed.Hint(), true}, {NumberTwo.Hint(), true}}, 1},
+
+		{"SixColor touched by color hint", ModeSixColor, []args{{ColorRed.Hint(), true}}, 5},
+		{"SixColor touched by rainbow hint", ModeSixColor, []args{{ColorRainbow.Hint(), true}}, 5},
+
+		{"Rainbow touched by color hint", ModeRainbow, []args{{ColorRed.Hint(), true}}, 10},
+		{"Rainbow not touched by color hint", ModeRainbow, []args{{ColorRed.Hint(), false}}, 20},
+		{"Rainbow touched by two color hints", ModeRainbow, []args{{ColorRed.Hint(), true}, {ColorBlue.Hint(), true}}, 5},
+		{"DarkRainbow touched by color hint", ModeDarkRainbow, []args{{ColorRed.Hint(), true}}, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := NewKnowledge(tt.mode)
+			for _, a := range tt.args {
+				k.Learn(a.hint, a.touched, tt.mode)
+			}
+			if got := k.Possible.Len(); got != tt.want {
+				t.Errorf("Knowledge.Possible.Len() = %v, want %v (%v)", got, tt.want, k.Possible)
+			}
+			if k.Clued != tt.args[0].touched {
+				t.Errorf("Knowledge.Clued = %v, want %v", k.Clued, tt.args[0].touched)
+			}
+		})
+	}
+}
+
+func TestGameState_Apply_Knowledge(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	r1 := Card{ColorRed, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+
+	state := newTestState([]Card{r2}, []Card{b1, b1}, []Card{r1, r2, b1})
+	if err := state.Apply(Move{Kind: MoveHint, Hint: ColorRed.Hint(), ToPlayerID: testPlayerIDs[1]}); err != nil {
+		t.Fatalf("GameState.Apply() error = %v", err)
+	}
+	if err := state.Apply(Move{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: testPlayerIDs[0]}); err != nil {
+		t.Fatalf("GameState.Apply() error = %v", err)
+	}
+
+	knowledge := state.Players[1].Knowledge
+	if c, ok := knowledge[0].Known(); ok {
+		t.Errorf("Knowledge[0].Known() = %v, want unknown", c)
+	}
+	if got := knowledge[0].Possible.Len(); got != 5 {
+		t.Errorf("Knowledge[0].Possible.Len() = %v, want 5", got)
+	}
+	if got := knowledge[2].Possible.Len(); got != 20 {
+		t.Errorf("Knowledge[2].Possible.Len() = %v, want 20", got)
+	}
+
+	// playing a card removes its knowledge, and draws a fresh one
+	if err := state.Apply(Move{Kind: MovePlay, Index: 0}); err != nil {
+		t.Fatalf("GameState.Apply() error = %v", err)
+	}
+	knowledge = state.Players[0].Knowledge
+	if len(knowledge) != 2 || !knowledge[0].Clued || knowledge[1].Clued {
+		t.Errorf("Knowledge = %v, want one clued and one unclued card", knowledge)
+	}
+}
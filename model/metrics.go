@@ -0,0 +1,129 @@
+package model
+
+import (
+	"math"
+)
+
+// Metrics represents statistics about the progress of a game, as tracked by experienced players.
+//
+// Metrics only consist of integers, so that they can always be encoded as JSON.
+// Use the Efficiency and RequiredEfficiency methods to compute the corresponding ratios.
+type Metrics struct {
+	// Pace is the number of cards that can still be discarded without lowering the maximum score.
+	// It is the current score plus the number of turns in which a card can still be played, minus MaxScore.
+	// Once the pace is negative, the maximum score can no longer be achieved.
+	Pace int `json:"pace"`
+
+	// CardsGotten is the number of cards played successfully plus the number of clued cards in hands that are not known to be trash.
+	// Whether a clued card is known to be trash is determined from the Knowledge of its owner only.
+	// Metrics thus only depend on public information, and can be shown to every player.
+	CardsGotten int `json:"cardsGotten"`
+
+	// CluesSpent is the number of hints given so far.
+	CluesSpent int `json:"cluesSpent"`
+
+	// CardsNeeded is the number of cards that still need to be gotten to achieve MaxScore.
+	CardsNeeded int `json:"cardsNeeded"`
+
+	// FutureClues is the number of hints that can still be given.
+	// It is the number of hints available plus the pace, if positive.
+	FutureClues int `json:"futureClues"`
+}
+
+// Efficiency returns the achieved efficiency, that is the number of cards gotten per clue spent.
+// When no clues have been spent, returns positive infinity, or 0 when no cards have been gotten either.
+func (m Metrics) Efficiency() float64 {
+	return ratio(m.CardsGotten, m.CluesSpent)
+}
+
+// RequiredEfficiency returns the efficiency required to achieve the maximum score, that is the number of cards needed per future clue.
+// When no future clues are available, returns positive infinity, or 0 when no cards are needed either.
+func (m Metrics) RequiredEfficiency() float64 {
+	return ratio(m.CardsNeeded, m.FutureClues)
+}
+
+// ratio returns a / b, see Metrics.Efficiency.
+func ratio(a, b int) float64 {
+	switch {
+	case b != 0:
+		return float64(a) / float64(b)
+	case a == 0:
+		return 0
+	}
+	return math.Inf(1)
+}
+
+// Metrics returns the current Metrics of this game.
+// They are also recorded in every EventTurn and EventGameOver.
+func (state *GameState) Metrics() Metrics {
+	var m Metrics
+
+	played := 0
+	for _, number := range state.ColorPiles {
+		played += int(number)
+	}
+	maxScore := state.MaxScore()
+
+	turns := len(state.Players)
+	if state.EndTurn != 0 {
+		turns = state.EndTurn - state.Turn
+	}
+	m.Pace = played + len(state.Stack) + turns - maxScore
+
+	m.CardsGotten = played
+	for _, p := range state.Players {
+		for _, k := range p.Knowledge {
+			if k.Clued && !state.knownTrash(k) {
+				m.CardsGotten++
+			}
+		}
+	}
+
+	for _, move := range state.Moves {
+		if move.Kind == MoveHint {
+			m.CluesSpent++
+		}
+	}
+
+	if m.CardsNeeded = maxScore - m.CardsGotten; m.CardsNeeded < 0 {
+		m.CardsNeeded = 0
+	}
+
+	m.FutureClues = int(state.Hints)
+	if m.Pace > 0 {
+		m.FutureClues += m.Pace
+	}
+	return m
+}
+
+// knownTrash checks if every card that is possible according to k is trash.
+func (state *GameState) knownTrash(k Knowledge) bool {
+	for _, card := range k.Possible.Cards() {
+		if state.Classify(card) != ClassTrash {
+			return false
+		}
+	}
+	return true
+}
+
+// Pace returns the current pace of this game, see Metrics.Pace.
+func (state *GameState) Pace() int {
+	return state.Metrics().Pace
+}
+
+// Efficiency returns the current achieved efficiency of this game, see Metrics.Efficiency.
+func (state *GameState) Efficiency() float64 {
+	return state.Metrics().Efficiency()
+}
+
+// RequiredEfficiency returns the current required efficiency of this game, see Metrics.RequiredEfficiency.
+func (state *GameState) RequiredEfficiency() float64 {
+	return state.Metrics().RequiredEfficiency()
+}
+
+// emitMetrics emits e along with the current Metrics.
+func (state *GameState) emitMetrics(e Event) {
+	m := state.Metrics()
+	e.Metrics = &m
+	state.emit(e)
+}
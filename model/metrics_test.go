@@ -0,0 +1,139 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGameState_Metrics(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	b2 := Card{ColorBlue, NumberTwo}
+	r1 := Card{ColorRed, NumberOne}
+
+	state := newTestState([]Card{r1, r1, r1, r1}, []Card{b1, b2}, []Card{r1, b1})
+	moves := []Move{
+		{Kind: MoveHint, Hint: ColorRed.Hint(), ToPlayerID: testPlayerIDs[1]},
+		{Kind: MoveHint, Hint: ColorBlue.Hint(), ToPlayerID: testPlayerIDs[0]},
+		{Kind: MovePlay, Index: 0},
+	}
+	for _, move := range moves {
+		if err := state.Apply(move); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+	}
+
+	// blue 1 has been played, blue 2 and red 1 are clued
+	want := Metrics{
+		Pace:        1 + 3 + 2 - 25,
+		CardsGotten: 3,
+		CluesSpent:  2,
+		CardsNeeded: 22,
+		FutureClues: 6,
+	}
+	if got := state.Metrics(); got != want {
+		t.Errorf("GameState.Metrics() = %+v, want %+v", got, want)
+	}
+	if got := state.Pace(); got != want.Pace {
+		t.Errorf("GameState.Pace() = %v, want %v", got, want.Pace)
+	}
+	if got := state.Efficiency(); got != 1.5 {
+		t.Errorf("GameState.Efficiency() = %v, want 1.5", got)
+	}
+	if got := state.RequiredEfficiency(); got != 22.0/6 {
+		t.Errorf("GameState.RequiredEfficiency() = %v, want %v", got, 22.0/6)
+	}
+
+	last := state.History[len(state.History)-1]
+	if last.Kind != EventTurn || last.Metrics == nil || *last.Metrics != want {
+		t.Errorf("last event = %v, want EventTurn with Metrics %+v", last, want)
+	}
+}
+
+func TestGameState_Metrics_KnownTrash(t *testing.T) {
+	r1 := Card{ColorRed, NumberOne}
+	b2 := Card{ColorBlue, NumberTwo}
+
+	state := newTestState([]Card{b2, b2, b2}, []Card{b2, b2}, []Card{r1, b2})
+	state.ColorPiles[ColorRed] = NumberOne
+	moves := []Move{
+		{Kind: MoveHint, Hint: ColorRed.Hint(), ToPlayerID: testPlayerIDs[1]},
+		{Kind: MoveHint, Hint: NumberTwo.Hint(), ToPlayerID: testPlayerIDs[0]},
+		{Kind: MoveHint, Hint: NumberOne.Hint(), ToPlayerID: testPlayerIDs[1]},
+	}
+	for _, move := range moves {
+		if err := state.Apply(move); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+	}
+
+	// both blue twos are gotten, the red one is known to be trash
+	if got := state.Metrics().CardsGotten; got != 1+2 {
+		t.Errorf("GameState.Metrics().CardsGotten = %v, want %v", got, 1+2)
+	}
+}
+
+func TestGameState_Metrics_Redacted(t *testing.T) {
+	r1 := Card{ColorRed, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+	b2 := Card{ColorBlue, NumberTwo}
+	g3 := Card{ColorGreen, NumberThree}
+
+	// the second player holds a trash red one in one game, and a playable red two in the other.
+	// the history they see must be identical in both games.
+	var histories [][]Event
+	for _, own := range []Card{r1, r2} {
+		state := newTestState([]Card{g3, g3, g3}, []Card{b2, g3}, []Card{own, b2})
+		state.ColorPiles[ColorRed] = NumberOne
+
+		moves := []Move{
+			{Kind: MoveHint, Hint: ColorRed.Hint(), ToPlayerID: testPlayerIDs[1]},
+			{Kind: MoveHint, Hint: ColorBlue.Hint(), ToPlayerID: testPlayerIDs[0]},
+			{Kind: MoveDiscard, Index: 1},
+		}
+		for _, move := range moves {
+			if err := state.Apply(move); err != nil {
+				t.Fatalf("GameState.Apply() error = %v", err)
+			}
+		}
+
+		history, err := state.PlayerHistory(testPlayerIDs[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		histories = append(histories, history)
+	}
+
+	if !reflect.DeepEqual(histories[0], histories[1]) {
+		t.Error("GameState.PlayerHistory() reveals information about the hand of the viewer")
+	}
+}
+
+func TestMetrics_Efficiency(t *testing.T) {
+	tests := []struct {
+		name         string
+		metrics      Metrics
+		wantEff      float64
+		wantRequired float64
+	}{
+		{"start of game", Metrics{CardsNeeded: 25, FutureClues: 10}, 0, 2.5},
+		{"no clues spent", Metrics{CardsGotten: 2}, math.Inf(1), 0},
+		{"no future clues", Metrics{CardsGotten: 4, CluesSpent: 2, CardsNeeded: 3}, 2, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.metrics.Efficiency(); got != tt.wantEff {
+				t.Errorf("Metrics.Efficiency() = %v, want %v", got, tt.wantEff)
+			}
+			if got := tt.metrics.RequiredEfficiency(); got != tt.wantRequired {
+				t.Errorf("Metrics.RequiredEfficiency() = %v, want %v", got, tt.wantRequired)
+			}
+
+			// metrics can always be encoded
+			if _, err := json.Marshal(tt.metrics); err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+			}
+		})
+	}
+}
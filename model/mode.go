@@ -20,13 +20,9 @@ const (
 )
 
 // Valid checks if this GameMode is valid.
-// A GameMode is valid if it is a known gamemode and does not have a different value,
+// A GameMode is valid if a Variant has been registered for it, see RegisterVariant.
 func (mode GameMode) Valid() bool {
-	switch mode {
-	case ModeFiveColor, ModeSixColor, ModeRainbow, ModeDarkRainbow:
-		return true
-	}
-	return false
+	return mode.Variant() != nil
 }
 
 // Count counts how many times the provided card should occur in a new stack of this GameMode.
@@ -34,60 +30,35 @@ func (mode GameMode) Valid() bool {
 //
 // When a card is not allowed in a specified GameMode, returns 0.
 func (mode GameMode) Count(card Card) int {
-
 	// Internally, this function is relied upon as the source of truth for some methods.
 	// It should not be reimplemented based on other methods.
 
-	// Only rainbow cards have to be treated special.
-	// In FiveColor mode, no rainbow cards occur.
-	// In DarkRainbow, each rainbow card occurs exactly once.
-
-	if card.Color == ColorRainbow {
-		switch mode {
-		case ModeFiveColor: // Five Color has no rainbow cards
-			return 0
-		case ModeDarkRainbow: // Dark Rainbow has each rainbow color once
-			return 1
-		}
-	}
-
-	// for all the 'regular' colors
-	// - a 1 occurs 3 times
-	// - a 2, 3 or 4 occur twice
-	// - a 5 occurs once
-
-	switch card.Number {
-	case NumberOne:
-		return 3
-	case NumberTwo, NumberThree, NumberFour:
-		return 2
-	case NumberFive:
-		return 1
-	}
-	panic("mode.CardCount(): precondition failed: card.Valid() is false")
+	return mode.mustVariant("mode.Count()").Count(card)
 }
 
 // TotalCards counts the total number of cards in a given GameMode.
 // This functions assumes that GameMode is valid, and may call panic if this is not the case.
-func (mode GameMode) TotalCards() int {
-	// A valid implementation of this function might also be:
-	//
-	//   var total int
-	//	 ForEachValidCard(func (c Card){ total += mode.CardCount(c) })
-	//   return total
-	//
-	// But this seems unneccessarily complicated.
-	// Instead we just pre-compute the values and return them here.
+func (mode GameMode) TotalCards() (total int) {
+	variant := mode.mustVariant("mode.TotalCards()")
+	ForEachValidCard(func(c Card) {
+		total += variant.Count(c)
+	})
+	return total
+}
+
+// Suits returns the colors of the piles played in this GameMode.
+// This functions assumes that GameMode is valid, and may call panic if this is not the case.
+func (mode GameMode) Suits() []CardColor {
+	return mode.mustVariant("mode.Suits()").Suits()
+}
 
-	switch mode {
-	case ModeFiveColor:
-		return 50
-	case ModeSixColor, ModeRainbow:
-		return 60
-	case ModeDarkRainbow:
-		return 55
+// mustVariant returns the Variant of this mode, or panics with a message that fn was called with an invalid mode.
+func (mode GameMode) mustVariant(fn string) Variant {
+	variant := mode.Variant()
+	if variant == nil {
+		panic(fn + ": precondition failed: mode.Valid() is false")
 	}
-	panic("mode.TotalCards(): precondition failed: mode.Valid() is false")
+	return variant
 }
 
 // NewStack returns a new stack of cards for the given GameMode
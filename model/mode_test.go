@@ -25,6 +25,21 @@ func TestGameMode_Valid(t *testing.T) {
 	}
 }
 
+func TestGameMode_Suits(t *testing.T) {
+	for _, mode := range Modes() {
+		want := mode.Suits()
+
+		// modifying the returned slice does not affect the mode
+		mode.Suits()[0] = ColorUnspecified
+		if got := mode.Suits(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: GameMode.Suits() = %v, want %v", mode, got, want)
+		}
+	}
+	if validColors[0] != ColorBlue {
+		t.Errorf("validColors[0] = %v, want %v", validColors[0], ColorBlue)
+	}
+}
+
 func TestGameMode_Count(t *testing.T) {
 	type args struct {
 		card Card
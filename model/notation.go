@@ -0,0 +1,221 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// This file implements a compact text notation for cards, hints and moves.
+//
+// A card is written as the letter of its color followed by its number, e.g. "b3" or "m5".
+// The letters are the first letters of the colors, except for rainbow which is written as "m" (for multi).
+//
+// A hint is written as either the letter or name of a color, or a number, e.g. "r", "red" or "4".
+//
+// Moves are written as "play 2", "discard 1" or "clue p3 blue", when parsing "hint" may be used instead of "clue".
+// Cards in a hand and players are numbered starting at 1, that is "play 2" plays the card at index 1,
+// and "clue p3 blue" hints the third player in the game.
+// The ID of the player making a move is not part of the notation.
+//
+// Parsing is case-insensitive, formatting always produces lower case.
+// Parsing a formatted card, hint or move returns the original value.
+
+// ErrInvalidNotation is returned when a card, hint or move can not be parsed or formatted.
+var ErrInvalidNotation = errors.New("Notation: Invalid notation")
+
+// colorLetters maps each color to the letter used for it in the notation
+var colorLetters = map[CardColor]string{
+	ColorBlue:    "b",
+	ColorGreen:   "g",
+	ColorRed:     "r",
+	ColorWhite:   "w",
+	ColorYellow:  "y",
+	ColorRainbow: "m",
+}
+
+// Short returns the letter used for this color in the compact notation.
+// When the color is not valid, returns "?".
+func (c CardColor) Short() string {
+	if letter, ok := colorLetters[c]; ok {
+		return letter
+	}
+	return "?"
+}
+
+// parseColor parses the letter or name of a color
+func parseColor(s string) (CardColor, bool) {
+	for _, color := range validColors {
+		if s == colorLetters[color] || s == string(color) {
+			return color, true
+		}
+	}
+	return ColorUnspecified, false
+}
+
+// parseNumber parses a number
+func parseNumber(s string) (CardNumber, bool) {
+	for _, number := range validNumbers {
+		if s == number.String() {
+			return number, true
+		}
+	}
+	return NumberUnspecified, false
+}
+
+// Short returns this card in compact notation, e.g. "b3".
+// Invalid colors and numbers are written as "?".
+func (c Card) Short() string {
+	return c.Color.Short() + c.Number.String()
+}
+
+// ParseCard parses a card in compact notation, see Card.Short.
+func ParseCard(s string) (Card, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) != 2 {
+		return Card{}, errors.Wrapf(ErrInvalidNotation, "Card %q", s)
+	}
+
+	color, okColor := parseColor(s[:1])
+	number, okNumber := parseNumber(s[1:])
+	if !okColor || !okNumber {
+		return Card{}, errors.Wrapf(ErrInvalidNotation, "Card %q", s)
+	}
+	return Card{Color: color, Number: number}, nil
+}
+
+// Short returns this hint in compact notation, e.g. "r" or "4".
+// When the hint is not valid, returns "?".
+func (h Hint) Short() string {
+	switch {
+	case h.IsColorHint():
+		return h.Color.Short()
+	case h.IsNumberHint():
+		return h.Number.String()
+	}
+	return "?"
+}
+
+// ParseHint parses a hint in compact notation, see Hint.Short.
+// Besides the letter of a color, also accepts its full name.
+func ParseHint(s string) (Hint, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if color, ok := parseColor(s); ok {
+		return color.Hint(), nil
+	}
+	if number, ok := parseNumber(s); ok {
+		return number.Hint(), nil
+	}
+	return Hint{}, errors.Wrapf(ErrInvalidNotation, "Hint %q", s)
+}
+
+// ParseMove parses a move in this game written in compact notation.
+// The ID of the returned move is not set.
+func (state *GameState) ParseMove(s string) (Move, error) {
+	return parseMove(s, state.playerIDs())
+}
+
+// FormatMove writes a move in this game in compact notation, e.g. "play 2" or "clue p3 blue".
+// The ID of the move is ignored.
+func (state *GameState) FormatMove(move Move) (string, error) {
+	return formatMove(move, state.playerIDs())
+}
+
+// ParseMove is like GameState.ParseMove.
+func (view *View) ParseMove(s string) (Move, error) {
+	return parseMove(s, view.playerIDs())
+}
+
+// FormatMove is like GameState.FormatMove.
+func (view *View) FormatMove(move Move) (string, error) {
+	return formatMove(move, view.playerIDs())
+}
+
+// playerIDs returns the ids of the players in this game, in order.
+func (state *GameState) playerIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(state.Players))
+	for i, p := range state.Players {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+// playerIDs returns the ids of the players in this game, in order.
+func (view *View) playerIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(view.Players))
+	for i, p := range view.Players {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+// parseMove parses a move in a game with the provided players.
+func parseMove(s string, players []uuid.UUID) (Move, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return Move{}, errors.Wrapf(ErrInvalidNotation, "Move %q", s)
+	}
+
+	switch {
+	case (fields[0] == "play" || fields[0] == "discard") && len(fields) == 2:
+		index, err := strconv.Atoi(fields[1])
+		if err != nil || index < 1 {
+			return Move{}, errors.Wrapf(ErrInvalidNotation, "Move %q", s)
+		}
+
+		kind := MovePlay
+		if fields[0] == "discard" {
+			kind = MoveDiscard
+		}
+		return Move{Kind: kind, Index: index - 1}, nil
+
+	case (fields[0] == "clue" || fields[0] == "hint") && len(fields) == 3:
+		seat, err := strconv.Atoi(strings.TrimPrefix(fields[1], "p"))
+		if !strings.HasPrefix(fields[1], "p") || err != nil || seat < 1 || seat > len(players) {
+			return Move{}, errors.Wrapf(ErrInvalidNotation, "Move %q", s)
+		}
+
+		hint, err := ParseHint(fields[2])
+		if err != nil {
+			return Move{}, errors.Wrapf(ErrInvalidNotation, "Move %q", s)
+		}
+		return Move{Kind: MoveHint, Hint: hint, ToPlayerID: players[seat-1]}, nil
+	}
+
+	return Move{}, errors.Wrapf(ErrInvalidNotation, "Move %q", s)
+}
+
+// formatMove formats a move in a game with the provided players.
+func formatMove(move Move, players []uuid.UUID) (string, error) {
+	switch move.Kind {
+	case MovePlay, MoveDiscard:
+		if move.Index < 0 {
+			return "", ErrInvalidIndex
+		}
+		return string(move.Kind) + " " + strconv.Itoa(move.Index+1), nil
+	case MoveHint:
+		seat := -1
+		for i, id := range players {
+			if id == move.ToPlayerID {
+				seat = i
+			}
+		}
+		if seat == -1 {
+			return "", ErrUnknownPlayer
+		}
+
+		var hint string
+		switch {
+		case move.Hint.IsColorHint():
+			hint = string(move.Hint.Color)
+		case move.Hint.IsNumberHint():
+			hint = move.Hint.Number.String()
+		default:
+			return "", errors.Wrapf(ErrInvalidNotation, "Hint %v", move.Hint)
+		}
+		return "clue p" + strconv.Itoa(seat+1) + " " + hint, nil
+	}
+	return "", ErrInvalidMoveKind
+}
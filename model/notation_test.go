@@ -0,0 +1,206 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Card
+		wantErr bool
+	}{
+		{"b3", Card{ColorBlue, NumberThree}, false},
+		{"m5", Card{ColorRainbow, NumberFive}, false},
+		{" Y1 ", Card{ColorYellow, NumberOne}, false},
+		{"w2", Card{ColorWhite, NumberTwo}, false},
+
+		{"", Card{}, true},
+		{"b", Card{}, true},
+		{"b6", Card{}, true},
+		{"x1", Card{}, true},
+		{"3b", Card{}, true},
+		{"b33", Card{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCard(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCard() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && errors.Cause(err) != ErrInvalidNotation {
+				t.Errorf("ParseCard() error = %v, want cause %v", err, ErrInvalidNotation)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCard_Short(t *testing.T) {
+	// every valid card round-trips
+	ForEachValidCard(func(c Card) {
+		got, err := ParseCard(c.Short())
+		if err != nil || got != c {
+			t.Errorf("ParseCard(%q) = %v, %v, want %v", c.Short(), got, err, c)
+		}
+	})
+
+	if got := (Card{Color: ColorRed}).Short(); got != "r?" {
+		t.Errorf("Card.Short() = %q, want %q", got, "r?")
+	}
+}
+
+func TestParseHint(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Hint
+		wantErr bool
+	}{
+		{"r", ColorRed.Hint(), false},
+		{"red", ColorRed.Hint(), false},
+		{"M", ColorRainbow.Hint(), false},
+		{"Rainbow", ColorRainbow.Hint(), false},
+		{"4", NumberFour.Hint(), false},
+
+		{"", Hint{}, true},
+		{"0", Hint{}, true},
+		{"purple", Hint{}, true},
+		{"r4", Hint{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseHint(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseHint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseHint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHint_Short(t *testing.T) {
+	var hints []Hint
+	for _, color := range validColors {
+		hints = append(hints, color.Hint())
+	}
+	for _, number := range validNumbers {
+		hints = append(hints, number.Hint())
+	}
+
+	for _, h := range hints {
+		got, err := ParseHint(h.Short())
+		if err != nil || got != h {
+			t.Errorf("ParseHint(%q) = %v, %v, want %v", h.Short(), got, err, h)
+		}
+	}
+
+	if got := (Hint{}).Short(); got != "?" {
+		t.Errorf("Hint.Short() = %q, want %q", got, "?")
+	}
+}
+
+func TestGameState_ParseMove(t *testing.T) {
+	state := newTestState(nil, []Card{}, []Card{}, []Card{})
+
+	tests := []struct {
+		input   string
+		want    Move
+		wantErr bool
+	}{
+		{"play 2", Move{Kind: MovePlay, Index: 1}, false},
+		{"Discard 1", Move{Kind: MoveDiscard, Index: 0}, false},
+		{"clue p3 blue", Move{Kind: MoveHint, Hint: ColorBlue.Hint(), ToPlayerID: testPlayerIDs[2]}, false},
+		{"clue p2 b", Move{Kind: MoveHint, Hint: ColorBlue.Hint(), ToPlayerID: testPlayerIDs[1]}, false},
+		{"hint p1 5", Move{Kind: MoveHint, Hint: NumberFive.Hint(), ToPlayerID: testPlayerIDs[0]}, false},
+
+		{"", Move{}, true},
+		{"play", Move{}, true},
+		{"play 0", Move{}, true},
+		{"play two", Move{}, true},
+		{"pass 1", Move{}, true},
+		{"clue p4 blue", Move{}, true},
+		{"clue 3 blue", Move{}, true},
+		{"clue p3 purple", Move{}, true},
+		{"clue p3", Move{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := state.ParseMove(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GameState.ParseMove() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GameState.ParseMove() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameState_FormatMove(t *testing.T) {
+	state := newTestState(nil, []Card{}, []Card{}, []Card{})
+
+	tests := []struct {
+		name    string
+		move    Move
+		want    string
+		wantErr error
+	}{
+		{"play", Move{Kind: MovePlay, Index: 1}, "play 2", nil},
+		{"discard", Move{Kind: MoveDiscard, Index: 0}, "discard 1", nil},
+		{"color hint", Move{Kind: MoveHint, Hint: ColorRainbow.Hint(), ToPlayerID: testPlayerIDs[2]}, "clue p3 rainbow", nil},
+		{"number hint", Move{Kind: MoveHint, Hint: NumberTwo.Hint(), ToPlayerID: testPlayerIDs[1]}, "clue p2 2", nil},
+
+		{"unknown kind", Move{Kind: "pass"}, "", ErrInvalidMoveKind},
+		{"negative index", Move{Kind: MovePlay, Index: -1}, "", ErrInvalidIndex},
+		{"unknown player", Move{Kind: MoveHint, Hint: NumberTwo.Hint()}, "", ErrUnknownPlayer},
+		{"invalid hint", Move{Kind: MoveHint, Hint: Hint{ColorRed, NumberTwo}, ToPlayerID: testPlayerIDs[1]}, "", ErrInvalidNotation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := state.FormatMove(tt.move)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("GameState.FormatMove() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GameState.FormatMove() = %q, want %q", got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			// formatted moves round-trip
+			back, err := state.ParseMove(got)
+			if err != nil || !reflect.DeepEqual(back, tt.move) {
+				t.Errorf("GameState.ParseMove(%q) = %v, %v, want %v", got, back, err, tt.move)
+			}
+		})
+	}
+}
+
+func TestView_ParseMove(t *testing.T) {
+	state := newTestState(nil, []Card{}, []Card{})
+	view, err := state.PlayerView(testPlayerIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	move := Move{Kind: MoveHint, Hint: ColorGreen.Hint(), ToPlayerID: testPlayerIDs[1]}
+	text, err := view.FormatMove(move)
+	if err != nil || text != "clue p2 green" {
+		t.Fatalf("View.FormatMove() = %q, %v, want %q", text, err, "clue p2 green")
+	}
+	if got, err := view.ParseMove(text); err != nil || !reflect.DeepEqual(got, move) {
+		t.Errorf("View.ParseMove() = %v, %v, want %v", got, err, move)
+	}
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+)
+
+// MaxHints is the default maximum number of hints available in a game.
+// A game starts out with this number of hints.
+const MaxHints = 8
+
+// MaxMisplays is the default number of misplays that immediately end the game.
+const MaxMisplays = 3
+
+// RuleOptions represents the configurable rules of a game.
+// The zero value is not valid, use DefaultRuleOptions instead.
+type RuleOptions struct {
+	// MaxHints is the maximum number of hints available.
+	// A game starts out with this number of hints.
+	MaxHints uint8 `json:"maxHints"`
+
+	// MaxMisplays is the number of misplays that immediately ends the game.
+	MaxMisplays uint8 `json:"maxMisplays"`
+
+	// HandSizes maps the supported numbers of players to the number of cards each player starts out with.
+	HandSizes map[int]int `json:"handSizes"`
+
+	// EmptyHints indicates if hints that do not touch any card may be given.
+	EmptyHints bool `json:"emptyHints,omitempty"`
+
+	// OneExtraCard and OneLessCard increase or decrease the size of each hand by one card.
+	OneExtraCard bool `json:"oneExtraCard,omitempty"`
+	OneLessCard  bool `json:"oneLessCard,omitempty"`
+
+	// ScoreZeroOnStrikeout indicates that a game that ended with OutcomeStrikeout has a score of 0.
+	ScoreZeroOnStrikeout bool `json:"scoreZeroOnStrikeout,omitempty"`
+}
+
+// DefaultRuleOptions returns the default rules of a game.
+//
+// There are MaxHints hints and the game ends after MaxMisplays misplays.
+// Games are played by 2 to 6 players, with 5 cards per hand for 2 or 3 players, 4 cards for 4 or 5 players and 3 cards for 6 players.
+func DefaultRuleOptions() *RuleOptions {
+	return &RuleOptions{
+		MaxHints:    MaxHints,
+		MaxMisplays: MaxMisplays,
+		HandSizes: map[int]int{
+			2: 5,
+			3: 5,
+			4: 4,
+			5: 4,
+			6: 3,
+		},
+	}
+}
+
+// ErrInvalidOptions is returned when starting a game with invalid RuleOptions.
+var ErrInvalidOptions = errors.New("GameState: Invalid rule options")
+
+// Valid checks if these options are valid.
+func (options *RuleOptions) Valid() bool {
+	if options.MaxHints == 0 || options.MaxMisplays == 0 || len(options.HandSizes) == 0 {
+		return false
+	}
+	if options.OneExtraCard && options.OneLessCard {
+		return false
+	}
+	for players, size := range options.HandSizes {
+		if players < 1 || size < 1 || (size == 1 && options.OneLessCard) {
+			return false
+		}
+	}
+	return true
+}
+
+// HandSize returns the number of cards each player starts out with when the game is played by the provided number of players.
+// When the number of players is not supported, returns 0.
+func (options *RuleOptions) HandSize(players int) int {
+	size, ok := options.HandSizes[players]
+	switch {
+	case !ok:
+		return 0
+	case options.OneExtraCard:
+		return size + 1
+	case options.OneLessCard:
+		return size - 1
+	}
+	return size
+}
+
+// PlayerRange returns the minimum and maximum number of players supported by these options.
+func (options *RuleOptions) PlayerRange() (minPlayers, maxPlayers int) {
+	for players := range options.HandSizes {
+		if minPlayers == 0 || players < minPlayers {
+			minPlayers = players
+		}
+		if players > maxPlayers {
+			maxPlayers = players
+		}
+	}
+	return minPlayers, maxPlayers
+}
+
+// Clone returns a deep copy of these options.
+func (options *RuleOptions) Clone() *RuleOptions {
+	clone := *options
+	clone.HandSizes = make(map[int]int, len(options.HandSizes))
+	for players, size := range options.HandSizes {
+		clone.HandSizes[players] = size
+	}
+	return &clone
+}
+
+// rules returns the options of this game.
+// When no options have been set, returns DefaultRuleOptions.
+func (state *GameState) rules() *RuleOptions {
+	if state.Options == nil {
+		return DefaultRuleOptions()
+	}
+	return state.Options
+}
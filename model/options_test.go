@@ -0,0 +1,135 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestRuleOptions_Valid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(options *RuleOptions)
+		want   bool
+	}{
+		{"default options", func(options *RuleOptions) {}, true},
+		{"no hints", func(options *RuleOptions) { options.MaxHints = 0 }, false},
+		{"no misplays", func(options *RuleOptions) { options.MaxMisplays = 0 }, false},
+		{"no hand sizes", func(options *RuleOptions) { options.HandSizes = nil }, false},
+		{"empty hand", func(options *RuleOptions) { options.HandSizes[2] = 0 }, false},
+		{"one card less than one card", func(options *RuleOptions) { options.HandSizes[2] = 1; options.OneLessCard = true }, false},
+		{"one extra and one less card", func(options *RuleOptions) { options.OneExtraCard = true; options.OneLessCard = true }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultRuleOptions()
+			tt.modify(options)
+			if got := options.Valid(); got != tt.want {
+				t.Errorf("RuleOptions.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleOptions_HandSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(options *RuleOptions)
+		players int
+		want    int
+	}{
+		{"2 players", func(options *RuleOptions) {}, 2, 5},
+		{"4 players", func(options *RuleOptions) {}, 4, 4},
+		{"6 players", func(options *RuleOptions) {}, 6, 3},
+		{"unsupported players", func(options *RuleOptions) {}, 1, 0},
+		{"one extra card", func(options *RuleOptions) { options.OneExtraCard = true }, 4, 5},
+		{"one less card", func(options *RuleOptions) { options.OneLessCard = true }, 2, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultRuleOptions()
+			tt.modify(options)
+			if got := options.HandSize(tt.players); got != tt.want {
+				t.Errorf("RuleOptions.HandSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if minPlayers, maxPlayers := DefaultRuleOptions().PlayerRange(); minPlayers != 2 || maxPlayers != 6 {
+		t.Errorf("RuleOptions.PlayerRange() = %v, %v, want 2, 6", minPlayers, maxPlayers)
+	}
+}
+
+func TestGameState_Start_Options(t *testing.T) {
+	options := DefaultRuleOptions()
+	options.MaxHints = 4
+	options.OneExtraCard = true
+
+	state := &GameState{Mode: ModeFiveColor, Options: options}
+	for i := 0; i < 3; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := state.Start(1); err != nil {
+		t.Fatalf("GameState.Start() error = %v", err)
+	}
+
+	if state.Hints != 4 {
+		t.Errorf("Hints = %v, want 4", state.Hints)
+	}
+	if len(state.Players[0].Hand) != 6 {
+		t.Errorf("len(Hand) = %v, want 6", len(state.Players[0].Hand))
+	}
+
+	invalid := &GameState{Mode: ModeFiveColor, Options: &RuleOptions{}}
+	if err := invalid.Start(1); err != ErrInvalidOptions {
+		t.Errorf("GameState.Start() error = %v, want %v", err, ErrInvalidOptions)
+	}
+}
+
+func TestGameState_Apply_Options(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+
+	t.Run("empty hints", func(t *testing.T) {
+		state := newTestState(nil, []Card{b1}, []Card{b1})
+		move := Move{Kind: MoveHint, Hint: ColorRed.Hint(), ToPlayerID: testPlayerIDs[1]}
+
+		if err := state.Apply(move); err != ErrEmptyHint {
+			t.Errorf("GameState.Apply() error = %v, want %v", err, ErrEmptyHint)
+		}
+		state.Options.EmptyHints = true
+		if err := state.Apply(move); err != nil {
+			t.Errorf("GameState.Apply() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("max hints", func(t *testing.T) {
+		state := newTestState(nil, []Card{b1}, []Card{b1})
+		state.Options.MaxHints = 2
+		state.Hints = 1
+
+		if err := state.Apply(Move{Kind: MoveDiscard, Index: 0}); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if err := state.Apply(Move{Kind: MoveDiscard, Index: 0}); err != ErrMaxHints {
+			t.Errorf("GameState.Apply() error = %v, want %v", err, ErrMaxHints)
+		}
+	})
+
+	t.Run("strikeout with zero score", func(t *testing.T) {
+		state := newTestState(nil, []Card{r2, b1}, []Card{b1})
+		state.Options.MaxMisplays = 1
+		state.Options.ScoreZeroOnStrikeout = true
+		state.ColorPiles[ColorBlue] = NumberThree
+
+		if err := state.Apply(Move{Kind: MovePlay, Index: 0}); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if state.Outcome != OutcomeStrikeout {
+			t.Errorf("Outcome = %v, want %v", state.Outcome, OutcomeStrikeout)
+		}
+		if state.Score() != 0 {
+			t.Errorf("Score() = %v, want 0", state.Score())
+		}
+	})
+}
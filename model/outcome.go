@@ -0,0 +1,80 @@
+package model
+
+// Outcome represents the way a game of Hanabi ended.
+type Outcome string
+
+// The different outcomes of a game.
+const (
+	// OutcomeNone indicates that the game is still in progress.
+	OutcomeNone Outcome = ""
+
+	// OutcomePerfect indicates that every color pile has been completed.
+	OutcomePerfect Outcome = "perfect"
+
+	// OutcomeStrikeout indicates that the game ended because the maximum number of misplays were made.
+	OutcomeStrikeout Outcome = "strikeout"
+
+	// OutcomeDeckout indicates that the game ended because the Stack ran out and every player had their final turn.
+	OutcomeDeckout Outcome = "deckout"
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeNone:
+		return "In Progress"
+	case OutcomePerfect:
+		return "Perfect Game"
+	case OutcomeStrikeout:
+		return "Strikeout"
+	case OutcomeDeckout:
+		return "Deckout"
+	}
+	return "?"
+}
+
+// Over checks if the game is over.
+func (state *GameState) Over() bool {
+	return state.Outcome != OutcomeNone
+}
+
+// Score returns the current score of the game.
+// The score is the sum of the top cards of all the color piles.
+//
+// When the ScoreZeroOnStrikeout option is set and the game ended with OutcomeStrikeout, the score is 0.
+func (state *GameState) Score() int {
+	return score(state.ColorPiles, state.Outcome, state.rules())
+}
+
+// score computes the score of a game with the provided piles, outcome and options.
+func score(piles map[CardColor]CardNumber, outcome Outcome, options *RuleOptions) (score int) {
+	if outcome == OutcomeStrikeout && options.ScoreZeroOnStrikeout {
+		return 0
+	}
+	for _, number := range piles {
+		score += int(number)
+	}
+	return score
+}
+
+// PerfectScore returns the score of a perfect game, that is the score when every color pile is completed.
+func (state *GameState) PerfectScore() int {
+	return len(state.ColorPiles) * int(NumberFive)
+}
+
+// checkEnd checks if the game has ended after a move and updates Outcome and EndTurn accordingly.
+func (state *GameState) checkEnd() {
+	// Once the last card has been drawn, every player gets exactly one more turn.
+	// That includes the player who drew the last card.
+	if len(state.Stack) == 0 && state.EndTurn == 0 {
+		state.EndTurn = state.Turn + len(state.Players)
+	}
+
+	switch {
+	case state.Misplays >= state.rules().MaxMisplays:
+		state.Outcome = OutcomeStrikeout
+	case state.Score() == state.PerfectScore():
+		state.Outcome = OutcomePerfect
+	case state.EndTurn != 0 && state.Turn >= state.EndTurn:
+		state.Outcome = OutcomeDeckout
+	}
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestGameState_Score(t *testing.T) {
+	state := newTestState(nil, nil, nil)
+	state.ColorPiles[ColorBlue] = NumberThree
+	state.ColorPiles[ColorRed] = NumberFive
+
+	if got := state.Score(); got != 8 {
+		t.Errorf("GameState.Score() = %v, want 8", got)
+	}
+	if got := state.PerfectScore(); got != 25 {
+		t.Errorf("GameState.PerfectScore() = %v, want 25", got)
+	}
+}
+
+func TestGameState_Outcome(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	r5 := Card{ColorRed, NumberFive}
+
+	t.Run("strikeout after three misplays", func(t *testing.T) {
+		state := newTestState([]Card{b1, b1, b1}, []Card{r5, r5}, []Card{r5, r5})
+		state.Misplays = MaxMisplays - 1
+
+		if err := state.Apply(Move{Kind: MovePlay, Index: 0}); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if state.Outcome != OutcomeStrikeout {
+			t.Errorf("Outcome = %v, want %v", state.Outcome, OutcomeStrikeout)
+		}
+		if err := state.Apply(Move{Kind: MovePlay, Index: 0}); err != ErrGameOver {
+			t.Errorf("GameState.Apply() error = %v, want %v", err, ErrGameOver)
+		}
+	})
+
+	t.Run("perfect game after completing all piles", func(t *testing.T) {
+		state := newTestState([]Card{b1, b1, b1}, []Card{r5, r5}, []Card{r5, r5})
+		for color := range state.ColorPiles {
+			state.ColorPiles[color] = NumberFive
+		}
+		state.ColorPiles[ColorRed] = NumberFour
+
+		if err := state.Apply(Move{Kind: MovePlay, Index: 0}); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if state.Outcome != OutcomePerfect {
+			t.Errorf("Outcome = %v, want %v", state.Outcome, OutcomePerfect)
+		}
+		if state.Score() != state.PerfectScore() {
+			t.Errorf("Score() = %v, want %v", state.Score(), state.PerfectScore())
+		}
+	})
+
+	t.Run("deckout after the final round", func(t *testing.T) {
+		state := newTestState([]Card{b1}, []Card{r5, r5}, []Card{r5, r5})
+		hint := Move{Kind: MoveHint, Hint: NumberFive.Hint()}
+
+		// player 0 draws the last card, then each player gets one more turn
+		state.Hints = 3
+		if err := state.Apply(Move{Kind: MoveDiscard, Index: 0}); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if state.EndTurn != 3 {
+			t.Errorf("EndTurn = %v, want 3", state.EndTurn)
+		}
+
+		hint.ToPlayerID = testPlayerIDs[0]
+		if err := state.Apply(hint); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if state.Over() {
+			t.Fatalf("Over() = true before the final turn")
+		}
+		hint.ToPlayerID = testPlayerIDs[1]
+		if err := state.Apply(hint); err != nil {
+			t.Fatalf("GameState.Apply() error = %v", err)
+		}
+		if state.Outcome != OutcomeDeckout {
+			t.Errorf("Outcome = %v, want %v", state.Outcome, OutcomeDeckout)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTurn is returned when rewinding to a turn that does not exist.
+var ErrInvalidTurn = errors.New("GameState: Turn does not exist")
+
+// Branch returns a new game that is identical to this game at the beginning of the provided turn.
+// The returned game does not share any state with this game, and both can be continued independently.
+//
+// Turn must be between 0 and the current Turn (inclusive), otherwise ErrInvalidTurn is returned.
+// The game is re-created from the Deck and the Moves made so far.
+func (state *GameState) Branch(turn int) (*GameState, error) {
+	if !state.Started {
+		return nil, ErrGameNotStarted
+	}
+	if turn < 0 || turn > len(state.Moves) {
+		return nil, ErrInvalidTurn
+	}
+
+	branch := &GameState{Mode: state.Mode, Options: state.rules().Clone()}
+	for _, p := range state.Players {
+		branch.Players = append(branch.Players, &Player{ID: p.ID})
+	}
+
+	if err := branch.StartWithDeck(state.Deck); err != nil {
+		return nil, errors.Wrap(err, "Branch: Unable to start game")
+	}
+	branch.Seed = state.Seed
+
+	for i, move := range state.Moves[:turn] {
+		if err := branch.Apply(move); err != nil {
+			return nil, errors.Wrapf(err, "Branch: Unable to apply move %d", i)
+		}
+	}
+
+	return branch, nil
+}
+
+// Rewind rewinds this game to the beginning of the provided turn.
+// All moves made in and after that turn are undone.
+//
+// To keep the original line of moves, use Branch instead.
+func (state *GameState) Rewind(turn int) error {
+	branch, err := state.Branch(turn)
+	if err != nil {
+		return err
+	}
+
+	*state = *branch
+	return nil
+}
+
+// Undo undoes the last move made in this game.
+// When no move has been made yet, returns ErrInvalidTurn.
+func (state *GameState) Undo() error {
+	return state.Rewind(state.Turn - 1)
+}
+
+// Clone returns a deep copy of this game.
+// The returned game does not share any state with this game.
+func (state *GameState) Clone() *GameState {
+	clone := *state
+
+	clone.Stack = append([]Card(nil), state.Stack...)
+	clone.Deck = append([]Card(nil), state.Deck...)
+	clone.Discarded = append([]Card(nil), state.Discarded...)
+
+	if state.Options != nil {
+		clone.Options = state.Options.Clone()
+	}
+
+	if state.ColorPiles != nil {
+		clone.ColorPiles = make(map[CardColor]CardNumber, len(state.ColorPiles))
+		for color, number := range state.ColorPiles {
+			clone.ColorPiles[color] = number
+		}
+	}
+
+	clone.Players = make([]*Player, len(state.Players))
+	for i, p := range state.Players {
+		clone.Players[i] = &Player{
+			ID:        p.ID,
+			Hand:      append([]Card(nil), p.Hand...),
+			Knowledge: append([]Knowledge(nil), p.Knowledge...),
+		}
+	}
+
+	clone.Moves = append([]Move(nil), state.Moves...)
+	clone.History = make([]Event, len(state.History))
+	for i, e := range state.History {
+		e.Touched = append([]int(nil), e.Touched...)
+		if e.Metrics != nil {
+			metrics := *e.Metrics
+			e.Metrics = &metrics
+		}
+		clone.History[i] = e
+	}
+
+	return &clone
+}
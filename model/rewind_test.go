@@ -0,0 +1,88 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGameState_Branch(t *testing.T) {
+	original := playRandomGame(t, ModeRainbow, 3, 42)
+	before := original.Clone()
+
+	for _, turn := range []int{0, 1, original.Turn / 2, original.Turn} {
+		branch, err := original.Branch(turn)
+		if err != nil {
+			t.Fatalf("GameState.Branch() error = %v", err)
+		}
+		if branch.Turn != turn {
+			t.Errorf("GameState.Branch().Turn = %v, want %v", branch.Turn, turn)
+		}
+		if !reflect.DeepEqual(branch.Moves, append([]Move(nil), original.Moves[:turn]...)) {
+			t.Errorf("GameState.Branch().Moves does not match original moves")
+		}
+	}
+
+	if !reflect.DeepEqual(original, before) {
+		t.Error("GameState.Branch() modified the original game")
+	}
+
+	for _, turn := range []int{-1, original.Turn + 1} {
+		if _, err := original.Branch(turn); err != ErrInvalidTurn {
+			t.Errorf("GameState.Branch(%d) error = %v, want %v", turn, err, ErrInvalidTurn)
+		}
+	}
+
+	// games without options use the default options
+	original.Options = nil
+	branch, err := original.Branch(original.Turn)
+	if err != nil {
+		t.Fatalf("GameState.Branch() error = %v", err)
+	}
+	if !reflect.DeepEqual(branch.Options, DefaultRuleOptions()) {
+		t.Errorf("GameState.Branch().Options = %v, want %v", branch.Options, DefaultRuleOptions())
+	}
+}
+
+func TestGameState_Rewind(t *testing.T) {
+	state := playRandomGame(t, ModeFiveColor, 2, 42)
+	last := state.Moves[len(state.Moves)-1]
+
+	if err := state.Undo(); err != nil {
+		t.Fatalf("GameState.Undo() error = %v", err)
+	}
+	if state.Over() {
+		t.Error("GameState.Undo() did not undo the final move")
+	}
+	if err := state.Apply(last); err != nil {
+		t.Fatalf("GameState.Apply() error = %v", err)
+	}
+	if !state.Over() {
+		t.Error("GameState.Apply() did not redo the final move")
+	}
+
+	if err := state.Rewind(0); err != nil {
+		t.Fatalf("GameState.Rewind() error = %v", err)
+	}
+	if state.Turn != 0 || len(state.Moves) != 0 {
+		t.Errorf("GameState.Rewind(0) did not rewind to the beginning")
+	}
+	if err := state.Undo(); err != ErrInvalidTurn {
+		t.Errorf("GameState.Undo() error = %v, want %v", err, ErrInvalidTurn)
+	}
+}
+
+func TestGameState_Clone(t *testing.T) {
+	state := playRandomGame(t, ModeSixColor, 4, 42)
+	clone := state.Clone()
+
+	if !reflect.DeepEqual(clone, state) {
+		t.Fatal("GameState.Clone() is not equal to the original")
+	}
+
+	clone.Players[0].Hand[0] = Card{}
+	clone.ColorPiles[ColorBlue] = NumberUnspecified
+	clone.History[0].Kind = EventGameOver
+	if reflect.DeepEqual(clone, state) {
+		t.Error("GameState.Clone() shares state with the original")
+	}
+}
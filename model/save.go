@@ -0,0 +1,308 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SaveVersion is the version of the format written by Save.
+//
+// The format consists of a JSON object with two keys.
+// The "version" key contains the version of the format, and the "game" key contains the JSON-encoded GameState.
+//
+// Version 0 refers to a bare JSON-encoded GameState that is not wrapped in such an object and may not include the Knowledge of players.
+// Version 1 does not include the Deck of the game.
+// Version 2 does not include the Options of the game.
+const SaveVersion = 3
+
+// savedGame is the JSON object written by Save
+type savedGame struct {
+	Version int             `json:"version"`
+	Game    json.RawMessage `json:"game"`
+}
+
+// Migration migrates the JSON encoding of a GameState from one version of the save format to the next.
+type Migration func(game json.RawMessage) (json.RawMessage, error)
+
+// migrations holds the registered migrations.
+// migrations[v] migrates from version v to version v + 1.
+var migrations = map[int]Migration{
+	0: migrateKnowledge,
+	1: migrateDeck,
+	2: migrateOptions,
+}
+
+// migrateKnowledge migrates from version 0 to version 1.
+// Version 0 is a bare GameState, which is decoded case-insensitively, but may not include the Knowledge of players.
+// Players without Knowledge are assumed to know nothing about the cards in their hand.
+func migrateKnowledge(game json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(game, &fields); err != nil {
+		return nil, err
+	}
+
+	var partial struct {
+		Mode    json.RawMessage
+		Players []map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(game, &partial); err != nil {
+		return nil, err
+	}
+
+	var mode GameMode
+	if err := json.Unmarshal(partial.Mode, &mode); err != nil || !mode.Valid() {
+		return game, nil // rejected when decoding the game
+	}
+
+	for _, player := range partial.Players {
+		if key, ok := findField(player, "knowledge"); ok {
+			if string(player[key]) != "null" {
+				continue
+			}
+			delete(player, key)
+		}
+
+		var hand []json.RawMessage
+		if key, ok := findField(player, "hand"); ok {
+			if err := json.Unmarshal(player[key], &hand); err != nil {
+				return nil, err
+			}
+		}
+
+		knowledge := make([]Knowledge, len(hand))
+		for i := range knowledge {
+			knowledge[i] = NewKnowledge(mode)
+		}
+
+		var err error
+		if player["knowledge"], err = json.Marshal(knowledge); err != nil {
+			return nil, err
+		}
+	}
+
+	key, ok := findField(fields, "players")
+	if !ok {
+		return game, nil
+	}
+	var err error
+	if fields[key], err = json.Marshal(partial.Players); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// findField returns the key of the field with the provided name in a JSON object, ignoring case.
+func findField(fields map[string]json.RawMessage, name string) (string, bool) {
+	for key := range fields {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// migrateOptions migrates from version 2 to version 3, which added the Options.
+// Games before version 3 were always played with the DefaultRuleOptions, which are added to started games.
+func migrateOptions(game json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(game, &fields); err != nil {
+		return nil, err
+	}
+
+	var partial struct {
+		Started bool         `json:"started"`
+		Options *RuleOptions `json:"options"`
+	}
+	if err := json.Unmarshal(game, &partial); err != nil {
+		return nil, err
+	}
+	if !partial.Started || partial.Options != nil {
+		return game, nil
+	}
+
+	var err error
+	if fields["options"], err = json.Marshal(DefaultRuleOptions()); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// migrateDeck migrates from version 1 to version 2, which added the Deck.
+// The Deck is reconstructed from the cards drawn so far, followed by the cards remaining in the Stack.
+func migrateDeck(game json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(game, &fields); err != nil {
+		return nil, err
+	}
+	if key, ok := findField(fields, "deck"); ok && string(fields[key]) != "null" {
+		return game, nil // already has a deck
+	}
+
+	var partial struct {
+		Stack   []Card  `json:"stack"`
+		History []Event `json:"history"`
+	}
+	if err := json.Unmarshal(game, &partial); err != nil {
+		return nil, err
+	}
+
+	var deck []Card
+	for _, event := range partial.History {
+		if event.Kind == EventDraw {
+			deck = append(deck, event.Card)
+		}
+	}
+	for i := len(partial.Stack) - 1; i >= 0; i-- {
+		deck = append(deck, partial.Stack[i])
+	}
+
+	var err error
+	if fields["deck"], err = json.Marshal(deck); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// RegisterMigration registers a migration from the provided version of the save format to the next one.
+// Any previously registered migration for the same version is replaced.
+//
+// This function is not goroutine safe, and should be called during initialization only.
+func RegisterMigration(version int, migration Migration) {
+	migrations[version] = migration
+}
+
+// ErrUnsupportedVersion is returned when loading a game with an unknown version of the save format.
+var ErrUnsupportedVersion = errors.New("Load: Unsupported save format version")
+
+// Save writes this game to w using the current version of the save format, see SaveVersion.
+// The saved game includes the Seed, Moves, History and Knowledge of all players.
+func (state *GameState) Save(w io.Writer) error {
+	game, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "Save: Unable to encode game")
+	}
+
+	err = json.NewEncoder(w).Encode(savedGame{
+		Version: SaveVersion,
+		Game:    game,
+	})
+	return errors.Wrap(err, "Save: Unable to write game")
+}
+
+// ErrInvalidSave is returned when loading a game that is not consistent, see Load.
+var ErrInvalidSave = errors.New("Load: Game is not consistent")
+
+// Load reads a game written by Save from r.
+//
+// Games saved with an older version of the save format are migrated to the current version first.
+// When no migration for an older version is registered, or the version is newer than SaveVersion, returns ErrUnsupportedVersion.
+//
+// The loaded game is checked for consistency, so that it can be continued safely.
+// When the game is not consistent, returns an error with cause ErrInvalidSave.
+func Load(r io.Reader) (*GameState, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.Wrap(err, "Load: Unable to read game")
+	}
+
+	// check if the game was wrapped with a version.
+	// if not, it is a bare version 0 game.
+	var versioned map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &versioned); err != nil {
+		return nil, errors.Wrap(err, "Load: Unable to read game")
+	}
+	saved := savedGame{Version: 0, Game: raw}
+	if _, ok := versioned["version"]; ok {
+		if err := json.Unmarshal(raw, &saved); err != nil {
+			return nil, errors.Wrap(err, "Load: Unable to read game")
+		}
+	}
+
+	if saved.Version < 0 || saved.Version > SaveVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	// migrate to the current version
+	for saved.Version < SaveVersion {
+		migration, ok := migrations[saved.Version]
+		if !ok {
+			return nil, ErrUnsupportedVersion
+		}
+
+		var err error
+		saved.Game, err = migration(saved.Game)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Load: Unable to migrate from version %d", saved.Version)
+		}
+		saved.Version++
+	}
+
+	var state GameState
+	if err := json.Unmarshal(saved.Game, &state); err != nil {
+		if errors.Cause(err) == ErrModeInvalid {
+			return nil, ErrModeInvalid
+		}
+		return nil, errors.Wrap(err, "Load: Unable to decode game")
+	}
+	if state.Started && !state.Mode.Valid() {
+		return nil, ErrModeInvalid
+	}
+	if err := state.validate(); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// validate checks that a loaded game is consistent.
+// It assumes that the Mode of a started game is valid.
+func (state *GameState) validate() error {
+	if state.Options != nil && !state.Options.Valid() {
+		return errors.Wrap(ErrInvalidSave, "Invalid rule options")
+	}
+
+	for i, p := range state.Players {
+		if len(p.Hand) != len(p.Knowledge) {
+			return errors.Wrapf(ErrInvalidSave, "Hand and Knowledge of player %d differ in length", i)
+		}
+	}
+
+	if !state.Started {
+		return nil
+	}
+
+	options := state.rules()
+	switch {
+	case options.HandSize(len(state.Players)) == 0:
+		return errors.Wrap(ErrInvalidSave, "Unsupported number of players")
+	case state.CurrentPlayer < 0 || state.CurrentPlayer >= len(state.Players):
+		return errors.Wrap(ErrInvalidSave, "CurrentPlayer out of range")
+	case state.Hints > options.MaxHints:
+		return errors.Wrap(ErrInvalidSave, "Too many hints")
+	case state.Misplays > options.MaxMisplays:
+		return errors.Wrap(ErrInvalidSave, "Too many misplays")
+	}
+
+	suits := state.Mode.Suits()
+	if len(state.ColorPiles) != len(suits) {
+		return errors.Wrap(ErrInvalidSave, "ColorPiles do not match the GameMode")
+	}
+	for _, color := range suits {
+		if _, ok := state.ColorPiles[color]; !ok {
+			return errors.Wrapf(ErrInvalidSave, "Missing ColorPile %s", color)
+		}
+	}
+
+	cards := append(append([]Card(nil), state.Stack...), state.Discarded...)
+	for _, p := range state.Players {
+		cards = append(cards, p.Hand...)
+	}
+	for _, card := range cards {
+		if !card.Legal(state.Mode) {
+			return errors.Wrapf(ErrInvalidSave, "Card %v is not legal in the GameMode", card)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestGameState_Save(t *testing.T) {
+	for _, mode := range []GameMode{ModeFiveColor, ModeSixColor, ModeRainbow, ModeDarkRainbow, testModeCustom} {
+		t.Run(string(mode), func(t *testing.T) {
+			original := playRandomGame(t, mode, 3, 42)
+
+			// rewind to the middle of the game, so that we have an in-progress game
+			if err := original.Rewind(original.Turn / 2); err != nil {
+				t.Fatalf("GameState.Rewind() error = %v", err)
+			}
+
+			var buffer bytes.Buffer
+			if err := original.Save(&buffer); err != nil {
+				t.Fatalf("GameState.Save() error = %v", err)
+			}
+			loaded, err := Load(&buffer)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(loaded, original) {
+				t.Error("Load() did not return the saved game")
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// version 0 games, as written by encoding the GameState before the save format was introduced
+	bare := map[string]string{
+		"started": `{"Mode":"five-color","Stack":[{"color":"green","number":3}],"Discarded":null,` +
+			`"ColorPiles":{"blue":0,"green":0,"red":0,"white":0,"yellow":0},"Hints":8,"Misplays":0,` +
+			`"Players":[{"ID":"00000000-0000-0000-0000-000000000001","Hand":[{"color":"red","number":1},{"color":"blue","number":2}]},` +
+			`{"ID":"00000000-0000-0000-0000-000000000002","Hand":[{"color":"white","number":1},{"color":"red","number":5}]}],` +
+			`"Started":true,"CurrentPlayer":1}`,
+		"not started": `{"Mode":"five-color","Stack":null,"Discarded":null,"ColorPiles":null,"Hints":0,"Misplays":0,` +
+			`"Players":[{"ID":"00000000-0000-0000-0000-000000000001","Hand":null}],"Started":false,"CurrentPlayer":0}`,
+	}
+	for name, input := range bare {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := Load(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if loaded.Mode != ModeFiveColor {
+				t.Errorf("Load() Mode = %v, want %v", loaded.Mode, ModeFiveColor)
+			}
+			for i, p := range loaded.Players {
+				if len(p.Knowledge) != len(p.Hand) {
+					t.Fatalf("Load() player %d has %d cards and %d knowledge", i, len(p.Hand), len(p.Knowledge))
+				}
+				for j, k := range p.Knowledge {
+					if k != NewKnowledge(ModeFiveColor) {
+						t.Errorf("Load() player %d Knowledge[%d] = %v, want %v", i, j, k, NewKnowledge(ModeFiveColor))
+					}
+				}
+			}
+		})
+	}
+
+	started, err := Load(strings.NewReader(bare["started"]))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	wantHand := []Card{{ColorWhite, NumberOne}, {ColorRed, NumberFive}}
+	if !reflect.DeepEqual(started.Players[1].Hand, wantHand) || started.CurrentPlayer != 1 || started.Hints != 8 {
+		t.Errorf("Load() = %v, want Hand %v", started, wantHand)
+	}
+	if err := started.Apply(Move{Kind: MovePlay, Index: 0}); err != nil {
+		t.Errorf("GameState.Apply() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"newer version", fmt.Sprintf(`{"version":%d,"game":{}}`, SaveVersion+1), ErrUnsupportedVersion},
+		{"negative version", `{"version":-1,"game":{}}`, ErrUnsupportedVersion},
+		{"invalid mode", `{"version":1,"game":{"mode":"unknown","started":true}}`, ErrModeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(strings.NewReader(tt.input)); err != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_Version1(t *testing.T) {
+	original := playRandomGame(t, ModeRainbow, 3, 42)
+	if err := original.Rewind(original.Turn / 2); err != nil {
+		t.Fatalf("GameState.Rewind() error = %v", err)
+	}
+
+	// version 1 did not include the deck
+	game, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(game, &fields); err != nil {
+		t.Fatal(err)
+	}
+	delete(fields, "deck")
+	delete(fields, "options")
+	saved, err := json.Marshal(map[string]interface{}{"version": 1, "game": fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(bytes.NewReader(saved))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Deck, original.Deck) {
+		t.Errorf("Load() did not reconstruct the deck")
+	}
+	if !reflect.DeepEqual(loaded.Options, DefaultRuleOptions()) {
+		t.Errorf("Load() Options = %v, want %v", loaded.Options, DefaultRuleOptions())
+	}
+	if _, err := loaded.Branch(0); err != nil {
+		t.Errorf("GameState.Branch() error = %v", err)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(state *GameState)
+	}{
+		{"knowledge missing", func(state *GameState) { state.Players[0].Knowledge = state.Players[0].Knowledge[1:] }},
+		{"current player out of range", func(state *GameState) { state.CurrentPlayer = 2 }},
+		{"negative current player", func(state *GameState) { state.CurrentPlayer = -1 }},
+		{"single player", func(state *GameState) { state.Players = state.Players[:1] }},
+		{"too many hints", func(state *GameState) { state.Hints = MaxHints + 1 }},
+		{"too many misplays", func(state *GameState) { state.Misplays = MaxMisplays + 1 }},
+		{"missing color pile", func(state *GameState) { delete(state.ColorPiles, ColorRed) }},
+		{"illegal card", func(state *GameState) { state.Players[1].Hand[0] = Card{ColorRainbow, NumberOne} }},
+		{"invalid options", func(state *GameState) { state.Options = &RuleOptions{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newTestState([]Card{{ColorRed, NumberOne}}, []Card{{ColorBlue, NumberTwo}}, []Card{{ColorGreen, NumberThree}})
+			tt.modify(state)
+
+			var buffer bytes.Buffer
+			if err := state.Save(&buffer); err != nil {
+				t.Fatalf("GameState.Save() error = %v", err)
+			}
+			if _, err := Load(&buffer); errors.Cause(err) != ErrInvalidSave {
+				t.Errorf("Load() error = %v, want %v", err, ErrInvalidSave)
+			}
+		})
+	}
+}
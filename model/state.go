@@ -15,38 +15,72 @@ import (
 // This state is represented openly, i.e. every single card can be seen.
 // This state is not goroutine safe, and excepts that only one goroutine accesses the game at any point.
 type GameState struct {
-	Mode GameMode
+	Mode GameMode `json:"mode"`
 
-	// Stack is the stack new cards are dran from
-	Stack []Card
+	// Stack is the stack new cards are dran from.
+	// The last card in the stack is drawn first.
+	Stack []Card `json:"stack"`
 
 	// Discarded is the stack of cards that have been discarded
-	Discarded []Card
+	Discarded []Card `json:"discarded"`
 
 	// ColorPiles represents the current number for each color that has been played.
 	// When a card has not yet been played, it will be NumberUnspecified.
-	ColorPiles map[CardColor]CardNumber
+	ColorPiles map[CardColor]CardNumber `json:"colorPiles"`
 
-	Hints    uint8 // current number of hints available
-	Misplays uint8 // number of misplays so far
+	Hints    uint8 `json:"hints"`    // current number of hints available, at most Options.MaxHints
+	Misplays uint8 `json:"misplays"` // number of misplays so far
+
+	// Options are the rules this game is played with.
+	// Like Mode, they should be set before the game is started.
+	// When nil, Start uses DefaultRuleOptions.
+	Options *RuleOptions `json:"options"`
 
 	// Players is the list of players
 	// We use a pointer so that we can modify the player.
-	Players []*Player
+	Players []*Player `json:"players"`
 
 	// Stated returns if the game has already been started
-	Started bool
+	Started bool `json:"started"`
 	// CurrentPlayer is the player who has to make a move next
-	CurrentPlayer int
+	CurrentPlayer int `json:"currentPlayer"`
+
+	// Turn is the number of moves that have been made so far.
+	Turn int `json:"turn"`
+
+	// EndTurn is the Turn at which the game ends because every player has had their final turn.
+	// It is set once the Stack is empty, and zero before that.
+	EndTurn int `json:"endTurn"`
+
+	// Outcome is the outcome of the game.
+	// While the game is in progress, it is OutcomeNone.
+	Outcome Outcome `json:"outcome"`
+	// Seed is the seed that was used to shuffle the Stack.
+	// It is set by Start, and can be used to Replay the game.
+	Seed int64 `json:"seed"`
+
+	// Deck is the order in which cards are drawn from the Stack.
+	// It includes cards dealt to the players at the start of the game.
+	Deck []Card `json:"deck"`
+
+	// Moves is the list of moves that have been made so far
+	Moves []Move `json:"moves"`
+
+	// History is the list of events that happened in this game so far
+	History []Event `json:"history"`
 }
 
 // Player represents a player in Hanabi
 type Player struct {
 	// ID represents a unique ID for the player
-	ID uuid.UUID
+	ID uuid.UUID `json:"id"`
 
 	// Hand is the Hand of the Player
-	Hand []Card
+	Hand []Card `json:"hand"`
+
+	// Knowledge holds what the player knows about each card in their hand.
+	// Knowledge[i] corresponds to Hand[i].
+	Knowledge []Knowledge `json:"knowledge"`
 }
 
 // MoveKind represents the kind of moves a player can make.
@@ -62,30 +96,34 @@ const (
 // Move represents a move a player can make
 type Move struct {
 	// Kind is the kind of move the player makes
-	Kind MoveKind
+	Kind MoveKind `json:"kind"`
 
 	// ID is the player making this move.
 	// In most cases, this field may be omitted and the GameState wil fill it automatically.
-	ID uuid.UUID
+	ID uuid.UUID `json:"id"`
 
 	// Index is the index into their hand that the player plays or discards
-	Index int
+	Index int `json:"index"`
 
 	// Hint represents the hint that is being given.
-	Hint Hint
+	Hint Hint `json:"hint"`
 	// ToPlayerID represents the player that is being hinted.
-	ToPlayerID uuid.UUID
+	ToPlayerID uuid.UUID `json:"toPlayerId"`
 }
 
 // ErrGameStarted represents an error that an action cannot be performed because the game has already been started
 var ErrGameStarted = errors.New("Game Already started")
 
 // AddPlayer adds a new player to the Game.
-// When the Game has already started, returns ErrGameStarted
+// When the Game has already started, returns ErrGameStarted.
+// When the Game already has the maximum number of players supported by its options, returns ErrInvalidPlayerCount.
 func (state *GameState) AddPlayer() (*Player, error) {
 	if state.Started {
 		return nil, ErrGameStarted
 	}
+	if _, maxPlayers := state.rules().PlayerRange(); len(state.Players) >= maxPlayers {
+		return nil, ErrInvalidPlayerCount
+	}
 
 	player := &Player{}
 
@@ -115,29 +153,94 @@ func (state *GameState) AddPlayer() (*Player, error) {
 	return player, nil
 }
 
+// RemovePlayer removes the player with the provided id from the Game, it is the inverse of AddPlayer.
+// The order of the remaining players is kept.
+// When the Game has already started, returns ErrGameStarted.
+// When no such player exists, returns ErrUnknownPlayer.
+func (state *GameState) RemovePlayer(id uuid.UUID) error {
+	if state.Started {
+		return ErrGameStarted
+	}
+	for i, p := range state.Players {
+		if p.ID == id {
+			state.Players = append(state.Players[:i], state.Players[i+1:]...)
+			return nil
+		}
+	}
+	return ErrUnknownPlayer
+}
+
 // ErrModeInvalid is an error that indicates that the GameMode selected is not valid.
 var ErrModeInvalid = errors.New("GameState: Mode is invalid")
 
-// ErrInvalidPlayerCount is an error that is returned if there is the wrong number of players
-var ErrInvalidPlayerCount = errors.New("GameState: There must be between 2 and 5 players")
+// ErrInvalidPlayerCount is an error that is returned if the number of players is not supported by the Options
+var ErrInvalidPlayerCount = errors.New("GameState: Number of players is not supported")
+
+// ErrInvalidDeck is an error that is returned if a deck does not consist of exactly the cards of the GameMode.
+var ErrInvalidDeck = errors.New("GameState: Deck does not match the GameMode")
 
 // Start sets up this Game by initializing all internal
 // data structures.
 // The seed is used to shuffle the stack, and thus determines all the randomness in the game.
 // If seed is 0, a random seed is picked.
+// The seed used is stored in Seed.
 func (state *GameState) Start(seed int64) error {
+	if err := state.checkStart(); err != nil {
+		return err
+	}
 
-	// This function has to initialize the game, i.e:
+	// Create a new random source
+	// When the seed is zero, use the current time.
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	random := rand.New(rand.NewSource(seed))
 
-	// - set Hints to the right number
-	// - set Misplays to the right number
-	// - initialize the color and discard piles.
-	// - create and shuffle the stack
-	// - distribute cards to all the players
-	// - determine the first player to play
+	// Shuffle a new deck with it
+	deck := state.Mode.NewStack()
+	random.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
 
-	// - setup Stack to contain all the cards
+	state.deal(deck)
+	state.Seed = seed
+	return nil
+}
+
+// StartWithDeck sets up this Game like Start, except that instead of shuffling cards are drawn in the order of deck.
+// That is deck[0] is the first card dealt to the first player, deck[1] the second and so on.
+//
+// Deck must contain exactly the cards of the GameMode, otherwise ErrInvalidDeck is returned.
+// Seed is set to 0.
+func (state *GameState) StartWithDeck(deck []Card) error {
+	if err := state.checkStart(); err != nil {
+		return err
+	}
+
+	// check that the deck contains the right cards
+	counts := make(map[Card]int)
+	for _, c := range deck {
+		if !c.Legal(state.Mode) {
+			return ErrInvalidDeck
+		}
+		counts[c]++
+	}
+	for c, count := range counts {
+		if state.Mode.Count(c) != count {
+			return ErrInvalidDeck
+		}
+	}
+	if len(deck) != state.Mode.TotalCards() {
+		return ErrInvalidDeck
+	}
+
+	state.deal(append([]Card(nil), deck...))
+	state.Seed = 0
+	return nil
+}
 
+// checkStart checks that this game can be started.
+func (state *GameState) checkStart() error {
 	if state.Started {
 		return ErrGameStarted
 	}
@@ -146,65 +249,73 @@ func (state *GameState) Start(seed int64) error {
 		return ErrModeInvalid
 	}
 
+	if !state.rules().Valid() {
+		return ErrInvalidOptions
+	}
+
+	if state.rules().HandSize(len(state.Players)) == 0 {
+		return ErrInvalidPlayerCount
+	}
+
+	return nil
+}
+
+// deal initializes all internal data structures and deals the cards from deck.
+// It assumes that checkStart() has succeeded.
+func (state *GameState) deal(deck []Card) {
+
+	// This function has to initialize the game, i.e:
+
+	// - set Hints to the right number
+	// - set Misplays to the right number
+	// - initialize the color and discard piles.
+	// - setup Stack to contain all the cards
+	// - distribute cards to all the players
+	// - determine the first player to play
+
+	// setup the options
+	state.Options = state.rules()
+
 	// setup hints and misplays
-	state.Hints = 8
+	state.Hints = state.Options.MaxHints
 	state.Misplays = 0
 
 	// setup the color piles
 	state.ColorPiles = make(map[CardColor]CardNumber)
-	state.ColorPiles[ColorBlue] = NumberUnspecified
-	state.ColorPiles[ColorGreen] = NumberUnspecified
-	state.ColorPiles[ColorRed] = NumberUnspecified
-	state.ColorPiles[ColorWhite] = NumberUnspecified
-	state.ColorPiles[ColorYellow] = NumberUnspecified
-	if state.Mode == ModeRainbow || state.Mode == ModeDarkRainbow {
-		state.ColorPiles[ColorRainbow] = NumberUnspecified
+	for _, color := range state.Mode.Suits() {
+		state.ColorPiles[color] = NumberUnspecified
 	}
 
 	// setup the stack
-	state.Stack = state.Mode.NewStack()
-
-	// Create a new random source
-	// When the seed is zero, use the current time.
-	source := rand.NewSource(seed)
-	if seed == 0 {
-		source.Seed(time.Now().UnixNano())
+	// cards are drawn from the end of the stack, so it is the reverse of the deck.
+	state.Deck = deck
+	state.Stack = make([]Card, len(deck))
+	for i, c := range deck {
+		state.Stack[len(deck)-i-1] = c
 	}
-	random := rand.New(source)
-
-	// Shuffle the stack with it
-	random.Shuffle(len(state.Stack), func(i, j int) {
-		state.Stack[i], state.Stack[j] = state.Stack[j], state.Stack[i]
-	})
 
 	// setup the discard pile
 	state.Discarded = make([]Card, 0, len(state.Stack))
 
-	// determine the number of cards per player
-	cardsPerPlayer := 0
-	switch len(state.Players) {
-	case 2, 3:
-		cardsPerPlayer = 5
-	case 4, 5:
-		cardsPerPlayer = 4
-	default:
-		return ErrInvalidPlayerCount
-	}
+	// reset the history
+	state.Moves = nil
+	state.History = nil
+	state.Turn = 0
 
-	cardsInStack := len(state.Stack)
+	// each player draws their hand in order
+	cardsPerPlayer := state.Options.HandSize(len(state.Players))
 	for _, p := range state.Players {
-		// make a hand for the player
 		p.Hand = make([]Card, 0, cardsPerPlayer)
-
-		// pick the right amount of cards from the deck
-		// and put them into the hand of the player
-		cardsInStack -= cardsPerPlayer
-		p.Hand = append(p.Hand, state.Stack[cardsInStack:]...)
-		state.Stack = state.Stack[:cardsInStack]
+		p.Knowledge = make([]Knowledge, 0, cardsPerPlayer)
+		for i := 0; i < cardsPerPlayer; i++ {
+			state.drawCard(p)
+		}
 	}
 
 	// the first player starts
 	state.CurrentPlayer = 0
-
-	return nil
+	state.EndTurn = 0
+	state.Outcome = OutcomeNone
+	state.Started = true
+	state.emitMetrics(Event{Kind: EventTurn, Player: state.Players[0].ID})
 }
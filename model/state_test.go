@@ -0,0 +1,219 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGameState_Start(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      GameMode
+		players   int
+		wantErr   error
+		wantHand  int
+		wantPiles int
+	}{
+		{"FiveColor with 2 players", ModeFiveColor, 2, nil, 5, 5},
+		{"SixColor with 3 players", ModeSixColor, 3, nil, 5, 6},
+		{"Rainbow with 4 players", ModeRainbow, 4, nil, 4, 6},
+		{"DarkRainbow with 5 players", ModeDarkRainbow, 5, nil, 4, 6},
+		{"FiveColor with 6 players", ModeFiveColor, 6, nil, 3, 5},
+
+		{"invalid mode", GameMode("invalid"), 2, ErrModeInvalid, 0, 0},
+		{"1 player", ModeFiveColor, 1, ErrInvalidPlayerCount, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &GameState{Mode: tt.mode}
+			for i := 0; i < tt.players; i++ {
+				if _, err := state.AddPlayer(); err != nil {
+					t.Fatalf("GameState.AddPlayer() error = %v", err)
+				}
+			}
+
+			err := state.Start(1)
+			if err != tt.wantErr {
+				t.Fatalf("GameState.Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if state.Started {
+					t.Error("GameState.Start() failed, but Started = true")
+				}
+				return
+			}
+
+			if !state.Started {
+				t.Error("GameState.Start() did not set Started")
+			}
+			if state.Hints != MaxHints {
+				t.Errorf("Hints = %v, want %v", state.Hints, MaxHints)
+			}
+			if len(state.ColorPiles) != tt.wantPiles {
+				t.Errorf("len(ColorPiles) = %v, want %v", len(state.ColorPiles), tt.wantPiles)
+			}
+
+			total := len(state.Stack)
+			for _, p := range state.Players {
+				if len(p.Hand) != tt.wantHand {
+					t.Errorf("len(Hand) = %v, want %v", len(p.Hand), tt.wantHand)
+				}
+				total += len(p.Hand)
+			}
+			if total != tt.mode.TotalCards() {
+				t.Errorf("total cards = %v, want %v", total, tt.mode.TotalCards())
+			}
+
+			if err := state.Start(1); err != ErrGameStarted {
+				t.Errorf("second GameState.Start() error = %v, want %v", err, ErrGameStarted)
+			}
+		})
+	}
+}
+
+func TestGameState_StartWithDeck(t *testing.T) {
+	deck := ModeFiveColor.NewStack()
+
+	state := &GameState{Mode: ModeFiveColor}
+	for i := 0; i < 2; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatalf("GameState.AddPlayer() error = %v", err)
+		}
+	}
+
+	if err := state.StartWithDeck(deck[:len(deck)-1]); err != ErrInvalidDeck {
+		t.Errorf("GameState.StartWithDeck() error = %v, want %v", err, ErrInvalidDeck)
+	}
+	if err := state.StartWithDeck(append(deck[:len(deck)-1], Card{ColorRainbow, NumberOne})); err != ErrInvalidDeck {
+		t.Errorf("GameState.StartWithDeck() error = %v, want %v", err, ErrInvalidDeck)
+	}
+
+	deck = ModeFiveColor.NewStack()
+	if err := state.StartWithDeck(deck); err != nil {
+		t.Fatalf("GameState.StartWithDeck() error = %v", err)
+	}
+	if !reflect.DeepEqual(state.Players[0].Hand, deck[:5]) {
+		t.Errorf("Players[0].Hand = %v, want %v", state.Players[0].Hand, deck[:5])
+	}
+	if !reflect.DeepEqual(state.Players[1].Hand, deck[5:10]) {
+		t.Errorf("Players[1].Hand = %v, want %v", state.Players[1].Hand, deck[5:10])
+	}
+	if next := state.Stack[len(state.Stack)-1]; next != deck[10] {
+		t.Errorf("next card = %v, want %v", next, deck[10])
+	}
+}
+
+func TestGameState_AddPlayer(t *testing.T) {
+	state := &GameState{Mode: ModeFiveColor}
+	for i := 0; i < 6; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatalf("GameState.AddPlayer() error = %v", err)
+		}
+	}
+	if _, err := state.AddPlayer(); err != ErrInvalidPlayerCount {
+		t.Errorf("GameState.AddPlayer() error = %v, want %v", err, ErrInvalidPlayerCount)
+	}
+	if len(state.Players) != 6 {
+		t.Errorf("len(Players) = %v, want 6", len(state.Players))
+	}
+}
+
+func TestGameState_RemovePlayer(t *testing.T) {
+	state := &GameState{Mode: ModeFiveColor}
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		player, err := state.AddPlayer()
+		if err != nil {
+			t.Fatalf("GameState.AddPlayer() error = %v", err)
+		}
+		ids = append(ids, player.ID)
+	}
+
+	if err := state.RemovePlayer(ids[1]); err != nil {
+		t.Fatalf("GameState.RemovePlayer() error = %v", err)
+	}
+	if len(state.Players) != 2 || state.Players[0].ID != ids[0] || state.Players[1].ID != ids[2] {
+		t.Errorf("Players = %v, want players %v and %v", state.Players, ids[0], ids[2])
+	}
+	if err := state.RemovePlayer(ids[1]); err != ErrUnknownPlayer {
+		t.Errorf("GameState.RemovePlayer() error = %v, want %v", err, ErrUnknownPlayer)
+	}
+
+	if err := state.Start(1); err != nil {
+		t.Fatalf("GameState.Start() error = %v", err)
+	}
+	if err := state.RemovePlayer(ids[0]); err != ErrGameStarted {
+		t.Errorf("GameState.RemovePlayer() error = %v, want %v", err, ErrGameStarted)
+	}
+}
+
+func TestGameState_SixPlayers(t *testing.T) {
+	deck := ModeFiveColor.NewStack()
+
+	state := &GameState{Mode: ModeFiveColor}
+	for i := 0; i < 6; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatalf("GameState.AddPlayer() error = %v", err)
+		}
+	}
+	if err := state.StartWithDeck(deck); err != nil {
+		t.Fatalf("GameState.StartWithDeck() error = %v", err)
+	}
+
+	// each player is dealt three cards in turn
+	for i, p := range state.Players {
+		if want := deck[3*i : 3*i+3]; !reflect.DeepEqual(p.Hand, want) {
+			t.Errorf("Players[%d].Hand = %v, want %v", i, p.Hand, want)
+		}
+	}
+	if len(state.Stack) != len(deck)-18 {
+		t.Fatalf("len(Stack) = %v, want %v", len(state.Stack), len(deck)-18)
+	}
+
+	// play until the game ends, alternating between hints and discards.
+	// every discard draws the next card from the stack.
+	next := 18
+	movesAfterEmpty := 0
+	for !state.Over() {
+		if want := state.Turn % 6; state.CurrentPlayer != want {
+			t.Fatalf("turn %d: CurrentPlayer = %v, want %v", state.Turn, state.CurrentPlayer, want)
+		}
+		if len(state.Stack) == 0 {
+			movesAfterEmpty++
+		}
+
+		player := state.Players[state.CurrentPlayer]
+		if state.Hints == MaxHints {
+			target := state.Players[(state.CurrentPlayer+1)%6]
+			move := Move{Kind: MoveHint, Hint: target.Hand[0].Number.Hint(), ToPlayerID: target.ID}
+			if err := state.Apply(move); err != nil {
+				t.Fatalf("turn %d: GameState.Apply() error = %v", state.Turn, err)
+			}
+			continue
+		}
+
+		drawing := len(state.Stack) > 0
+		if err := state.Apply(Move{Kind: MoveDiscard, Index: 0}); err != nil {
+			t.Fatalf("turn %d: GameState.Apply() error = %v", state.Turn, err)
+		}
+		if !drawing {
+			continue
+		}
+		if got := player.Hand[len(player.Hand)-1]; got != deck[next] {
+			t.Errorf("drawn card = %v, want %v", got, deck[next])
+		}
+		next++
+	}
+
+	if next != len(deck) {
+		t.Errorf("drew %v cards, want %v", next, len(deck))
+	}
+	if state.Outcome != OutcomeDeckout {
+		t.Errorf("Outcome = %v, want %v", state.Outcome, OutcomeDeckout)
+	}
+	if movesAfterEmpty != 6 {
+		t.Errorf("moves after last draw = %v, want 6", movesAfterEmpty)
+	}
+}
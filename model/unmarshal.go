@@ -0,0 +1,156 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// This file implements validating decoding of CardColor, CardNumber and GameMode.
+// Each of them implements encoding.TextUnmarshaler and json.Unmarshaler, and rejects unknown values.
+// The empty string (or for numbers 0) decodes into the unspecified value, as it is used for hints.
+//
+// Besides the canonical values, common aliases used by other Hanabi implementations are accepted.
+// Decoding is case-insensitive, encoding always produces the canonical values.
+
+// ErrInvalidColor is returned when decoding an unknown CardColor.
+var ErrInvalidColor = errors.New("CardColor: Unknown color")
+
+// ErrInvalidNumber is returned when decoding an unknown CardNumber.
+var ErrInvalidNumber = errors.New("CardNumber: Unknown number")
+
+// colorAliases are alternative names of colors, besides their name and short notation
+var colorAliases = map[string]CardColor{
+	"multi":      ColorRainbow,
+	"multicolor": ColorRainbow,
+}
+
+// UnmarshalText decodes the name of a color, its short notation (see CardColor.Short) or an alias.
+// When text is not a known color, returns ErrInvalidColor.
+func (c *CardColor) UnmarshalText(text []byte) error {
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	if s == "" {
+		*c = ColorUnspecified
+		return nil
+	}
+	if color, ok := parseColor(s); ok {
+		*c = color
+		return nil
+	}
+	if color, ok := colorAliases[s]; ok {
+		*c = color
+		return nil
+	}
+	return errors.Wrapf(ErrInvalidColor, "%q", string(text))
+}
+
+// UnmarshalJSON decodes a JSON string using UnmarshalText.
+func (c *CardColor) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, c.UnmarshalText)
+}
+
+// numberAliases are alternative names of numbers, besides their digits
+var numberAliases = map[string]CardNumber{
+	"one":   NumberOne,
+	"two":   NumberTwo,
+	"three": NumberThree,
+	"four":  NumberFour,
+	"five":  NumberFive,
+}
+
+// UnmarshalText decodes a number between 0 and 5 or the english name of a number between 1 and 5.
+// When text is not a known number, returns ErrInvalidNumber.
+func (n *CardNumber) UnmarshalText(text []byte) error {
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	if s == "" || s == "0" {
+		*n = NumberUnspecified
+		return nil
+	}
+	if number, ok := parseNumber(s); ok {
+		*n = number
+		return nil
+	}
+	if number, ok := numberAliases[s]; ok {
+		*n = number
+		return nil
+	}
+	return errors.Wrapf(ErrInvalidNumber, "%q", string(text))
+}
+
+// UnmarshalJSON decodes either a JSON number or a JSON string using UnmarshalText.
+func (n *CardNumber) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" || strings.HasPrefix(string(data), `"`) {
+		return unmarshalJSONString(data, n.UnmarshalText)
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return errors.Wrapf(err, "Expected a number, got %s", data)
+	}
+	return n.UnmarshalText([]byte(number.String()))
+}
+
+// modeAliases are alternative names of the built-in GameModes
+var modeAliases = map[string]GameMode{
+	"5-suit":      ModeFiveColor,
+	"5-suits":     ModeFiveColor,
+	"five-suit":   ModeFiveColor,
+	"5-color":     ModeFiveColor,
+	"no-variant":  ModeFiveColor,
+	"6-suit":      ModeSixColor,
+	"6-suits":     ModeSixColor,
+	"six-suit":    ModeSixColor,
+	"6-color":     ModeSixColor,
+	"multi":       ModeRainbow,
+	"dark-multi":  ModeDarkRainbow,
+	"darkrainbow": ModeDarkRainbow,
+}
+
+// UnmarshalText decodes the name of a valid GameMode or an alias of a built-in GameMode.
+//
+// The name of any registered GameMode is matched exactly first, see RegisterVariant.
+// Only the built-in GameModes are also matched case-insensitively and by their aliases.
+// For these, spaces and underscores are treated like dashes, e.g. "Dark Rainbow" decodes into ModeDarkRainbow.
+// When text is not a valid GameMode, returns ErrModeInvalid.
+func (mode *GameMode) UnmarshalText(text []byte) error {
+	if m := GameMode(text); m == "" || m.Valid() {
+		*mode = m
+		return nil
+	}
+
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	s = strings.NewReplacer(" ", "-", "_", "-").Replace(s)
+	if alias, ok := modeAliases[s]; ok {
+		s = string(alias)
+	}
+	for _, m := range builtinModes {
+		if string(m) == s {
+			*mode = m
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrModeInvalid, "%q", string(text))
+}
+
+// builtinModes are the GameModes that are matched case-insensitively by GameMode.UnmarshalText
+var builtinModes = []GameMode{ModeFiveColor, ModeSixColor, ModeRainbow, ModeDarkRainbow}
+
+// UnmarshalJSON decodes a JSON string using UnmarshalText.
+func (mode *GameMode) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, mode.UnmarshalText)
+}
+
+// unmarshalJSONString decodes data as a JSON string and passes it to unmarshalText.
+// A JSON null is ignored.
+func unmarshalJSONString(data []byte, unmarshalText func([]byte) error) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrapf(err, "Expected a string, got %s", data)
+	}
+	return unmarshalText([]byte(s))
+}
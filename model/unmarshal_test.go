@@ -0,0 +1,160 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestCardColor_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CardColor
+		wantErr error
+	}{
+		{`"blue"`, ColorBlue, nil},
+		{`"Red"`, ColorRed, nil},
+		{`"y"`, ColorYellow, nil},
+		{`"multi"`, ColorRainbow, nil},
+		{`"m"`, ColorRainbow, nil},
+		{`""`, ColorUnspecified, nil},
+		{`null`, ColorUnspecified, nil},
+
+		{`"purple"`, ColorUnspecified, ErrInvalidColor},
+		{`"?"`, ColorUnspecified, ErrInvalidColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got CardColor
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("CardColor.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CardColor.UnmarshalJSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := json.Unmarshal([]byte(`3`), new(CardColor)); err == nil {
+		t.Error("CardColor.UnmarshalJSON() accepted a number")
+	}
+}
+
+func TestCardNumber_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CardNumber
+		wantErr error
+	}{
+		{`3`, NumberThree, nil},
+		{`"4"`, NumberFour, nil},
+		{`"five"`, NumberFive, nil},
+		{`0`, NumberUnspecified, nil},
+		{`""`, NumberUnspecified, nil},
+		{`null`, NumberUnspecified, nil},
+
+		{`6`, NumberUnspecified, ErrInvalidNumber},
+		{`-1`, NumberUnspecified, ErrInvalidNumber},
+		{`2.5`, NumberUnspecified, ErrInvalidNumber},
+		{`"six"`, NumberUnspecified, ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got CardNumber
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("CardNumber.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CardNumber.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameMode_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    GameMode
+		wantErr error
+	}{
+		{`"five-color"`, ModeFiveColor, nil},
+		{`"5-suit"`, ModeFiveColor, nil},
+		{`"Six Color"`, ModeSixColor, nil},
+		{`"multi"`, ModeRainbow, nil},
+		{`"dark_rainbow"`, ModeDarkRainbow, nil},
+		{`""`, "", nil},
+
+		{`"Custom_Variant"`, testModeCustom, nil},
+
+		{`"seven-color"`, "", ErrModeInvalid},
+		{`"custom-variant"`, "", ErrModeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got GameMode
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("GameMode.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GameMode.UnmarshalJSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_Card(t *testing.T) {
+	// cards, hints and map keys are validated as well
+	var got struct {
+		Card  Card
+		Hint  Hint
+		Piles map[CardColor]CardNumber
+	}
+	input := `{"Card":{"color":"multi","number":"two"},"Hint":{"number":4},"Piles":{"blue":1,"r":"3"}}`
+	if err := json.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if want := (Card{ColorRainbow, NumberTwo}); got.Card != want {
+		t.Errorf("Card = %v, want %v", got.Card, want)
+	}
+	if want := NumberFour.Hint(); got.Hint != want {
+		t.Errorf("Hint = %v, want %v", got.Hint, want)
+	}
+	if want := map[CardColor]CardNumber{ColorBlue: NumberOne, ColorRed: NumberThree}; !reflect.DeepEqual(got.Piles, want) {
+		t.Errorf("Piles = %v, want %v", got.Piles, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Piles":{"purple":1}}`), &got); errors.Cause(err) != ErrInvalidColor {
+		t.Errorf("json.Unmarshal() error = %v, want %v", err, ErrInvalidColor)
+	}
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	// values encode as before and decode back
+	ForEachValidCard(func(c Card) {
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Card
+		if err := json.Unmarshal(data, &got); err != nil || got != c {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, got, err, c)
+		}
+	})
+
+	for _, mode := range Modes() {
+		data, err := json.Marshal(mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got GameMode
+		if err := json.Unmarshal(data, &got); err != nil || got != mode {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, got, err, mode)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Variant represents the rules of a GameMode.
+//
+// A Variant determines which suits are played, how many copies of each card there are, and how hints work.
+// Variants are registered for a GameMode using RegisterVariant.
+type Variant interface {
+	// Suits returns the colors of the piles that are played.
+	// The returned slice may be modified by the caller.
+	Suits() []CardColor
+
+	// Count returns how many times a valid card occurs in a new stack.
+	// When a card does not occur at all, returns 0.
+	Count(card Card) int
+
+	// HintLegal checks if a valid hint may be given.
+	HintLegal(hint Hint) bool
+
+	// Touches checks if a legal hint touches a card.
+	Touches(hint Hint, card Card) bool
+}
+
+// StandardVariant is a Variant with the standard distribution of cards.
+//
+// For each suit, there are three ones, two twos, threes and fours and a single five.
+// Number hints touch all cards of their number, color hints touch all cards of their color.
+type StandardVariant struct {
+	// Colors are the colors of the suits in this variant
+	Colors []CardColor
+
+	// MultiColor optionally is a color that is touched by every color hint.
+	// Hints of this color are not legal.
+	MultiColor CardColor
+
+	// SingleColor optionally is a color of which each number only occurs once.
+	SingleColor CardColor
+}
+
+// Suits returns a copy of the colors of the suits in this variant.
+func (v StandardVariant) Suits() []CardColor {
+	return append([]CardColor(nil), v.Colors...)
+}
+
+// hasSuit checks if color is one of the suits of v.
+func (v StandardVariant) hasSuit(color CardColor) bool {
+	for _, c := range v.Colors {
+		if c == color {
+			return true
+		}
+	}
+	return false
+}
+
+// Count counts how many times a card occurs in a new stack.
+// It panics if card is not valid.
+func (v StandardVariant) Count(card Card) int {
+	if !card.Number.Valid() {
+		panic("StandardVariant.Count(): precondition failed: card.Valid() is false")
+	}
+
+	if !v.hasSuit(card.Color) {
+		return 0
+	}
+
+	if v.SingleColor != ColorUnspecified && card.Color == v.SingleColor {
+		return 1
+	}
+
+	// for all the 'regular' colors
+	// - a 1 occurs 3 times
+	// - a 2, 3 or 4 occur twice
+	// - a 5 occurs once
+
+	switch card.Number {
+	case NumberOne:
+		return 3
+	case NumberFive:
+		return 1
+	}
+	return 2
+}
+
+// HintLegal checks if a hint is legal in this variant.
+// Number hints are always legal, color hints are legal for all suits except MultiColor.
+func (v StandardVariant) HintLegal(hint Hint) bool {
+	if !hint.Valid() {
+		return false
+	}
+	if hint.IsNumberHint() {
+		return true
+	}
+	return v.hasSuit(hint.Color) && (v.MultiColor == ColorUnspecified || hint.Color != v.MultiColor)
+}
+
+// Touches checks if a hint touches a card in this variant.
+func (v StandardVariant) Touches(hint Hint, card Card) bool {
+	if hint.IsColorHint() && v.MultiColor != ColorUnspecified && card.Color == v.MultiColor {
+		return true
+	}
+	return hint.Number == card.Number || hint.Color == card.Color
+}
+
+// fiveColors are the suits of the ModeFiveColor GameMode
+var fiveColors = []CardColor{ColorBlue, ColorGreen, ColorRed, ColorWhite, ColorYellow}
+
+// variants holds the registered variants
+var variants = map[GameMode]Variant{
+	ModeFiveColor:   StandardVariant{Colors: fiveColors},
+	ModeSixColor:    StandardVariant{Colors: validColors},
+	ModeRainbow:     StandardVariant{Colors: validColors, MultiColor: ColorRainbow},
+	ModeDarkRainbow: StandardVariant{Colors: validColors, MultiColor: ColorRainbow, SingleColor: ColorRainbow},
+}
+var variantsMutex sync.RWMutex
+
+// ErrVariantExists is returned when registering a variant for a GameMode that already has a variant.
+var ErrVariantExists = errors.New("RegisterVariant: GameMode already has a Variant")
+
+// ErrVariantNil is returned when registering a nil variant.
+var ErrVariantNil = errors.New("RegisterVariant: Variant is nil")
+
+// RegisterVariant registers variant as the rules of mode.
+// Afterwards mode is valid and can be used to play games.
+//
+// Only valid cards can be used by variants.
+// When mode already has a variant, returns ErrVariantExists.
+func RegisterVariant(mode GameMode, variant Variant) error {
+	if variant == nil {
+		return ErrVariantNil
+	}
+
+	variantsMutex.Lock()
+	defer variantsMutex.Unlock()
+
+	if _, ok := variants[mode]; ok {
+		return ErrVariantExists
+	}
+	variants[mode] = variant
+	return nil
+}
+
+// Variant returns the variant of this GameMode.
+// When mode is not valid, returns nil.
+func (mode GameMode) Variant() Variant {
+	variantsMutex.RLock()
+	defer variantsMutex.RUnlock()
+
+	return variants[mode]
+}
+
+// Modes returns all valid GameModes, sorted by name.
+func Modes() []GameMode {
+	variantsMutex.RLock()
+	defer variantsMutex.RUnlock()
+
+	modes := make([]GameMode, 0, len(variants))
+	for mode := range variants {
+		modes = append(modes, mode)
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	return modes
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testModeFourColor is a custom GameMode registered for testing.
+// It has only four suits and a multicolor white suit.
+const testModeFourColor GameMode = "test-four-color"
+
+// testModeCustom is a custom GameMode registered for testing.
+// Its name is not normalized, and it is otherwise like ModeSixColor.
+const testModeCustom GameMode = "Custom_Variant"
+
+func init() {
+	err := RegisterVariant(testModeFourColor, StandardVariant{
+		Colors:     []CardColor{ColorBlue, ColorGreen, ColorRed, ColorWhite},
+		MultiColor: ColorWhite,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if err := RegisterVariant(testModeCustom, StandardVariant{Colors: validColors}); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterVariant(t *testing.T) {
+	if err := RegisterVariant(ModeFiveColor, StandardVariant{}); err != ErrVariantExists {
+		t.Errorf("RegisterVariant() error = %v, want %v", err, ErrVariantExists)
+	}
+	if err := RegisterVariant("test-nil", nil); err != ErrVariantNil {
+		t.Errorf("RegisterVariant() error = %v, want %v", err, ErrVariantNil)
+	}
+
+	want := []GameMode{testModeCustom, ModeDarkRainbow, ModeFiveColor, ModeRainbow, ModeSixColor, testModeFourColor}
+	if got := Modes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Modes() = %v, want %v", got, want)
+	}
+}
+
+func TestStandardVariant(t *testing.T) {
+	mode := testModeFourColor
+
+	if !mode.Valid() {
+		t.Fatal("GameMode.Valid() = false for registered variant")
+	}
+	if got := mode.TotalCards(); got != 40 {
+		t.Errorf("GameMode.TotalCards() = %v, want 40", got)
+	}
+	if got := mode.Count(Card{ColorYellow, NumberOne}); got != 0 {
+		t.Errorf("GameMode.Count(Yellow 1) = %v, want 0", got)
+	}
+
+	tests := []struct {
+		name string
+		hint Hint
+		card Card
+		want bool
+	}{
+		{"Blue hint touches blue card", ColorBlue.Hint(), Card{ColorBlue, NumberOne}, true},
+		{"Blue hint touches white card", ColorBlue.Hint(), Card{ColorWhite, NumberOne}, true},
+		{"Blue hint does not touch red card", ColorBlue.Hint(), Card{ColorRed, NumberOne}, false},
+		{"One hint touches white one", NumberOne.Hint(), Card{ColorWhite, NumberOne}, true},
+		{"One hint does not touch white two", NumberOne.Hint(), Card{ColorWhite, NumberTwo}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hint.Matches(tt.card, mode); got != tt.want {
+				t.Errorf("Hint.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if ColorWhite.Hint().Legal(mode) || ColorYellow.Hint().Legal(mode) {
+		t.Error("Hint.Legal() = true for multicolor or missing suit")
+	}
+	if !ColorRed.Hint().Legal(mode) || !NumberFive.Hint().Legal(mode) {
+		t.Error("Hint.Legal() = false for regular hint")
+	}
+
+	// games can be played in the new mode
+	state := &GameState{Mode: mode}
+	for i := 0; i < 2; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := state.Start(1); err != nil {
+		t.Fatalf("GameState.Start() error = %v", err)
+	}
+	if state.PerfectScore() != 20 {
+		t.Errorf("GameState.PerfectScore() = %v, want 20", state.PerfectScore())
+	}
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// View represents a GameState as seen by a single player.
+//
+// Unlike a GameState, a View does not contain any information that is hidden from the viewer.
+// In particular, the cards in the hand of the viewer and the order of the stack are not included.
+// A View is a copy, and modifying it does not modify the GameState it was created from.
+type View struct {
+	// Viewer is the id of the player this view was created for
+	Viewer uuid.UUID `json:"viewer"`
+
+	Mode GameMode `json:"mode"`
+
+	// Options are the rules the game is played with
+	Options RuleOptions `json:"options"`
+
+	// StackSize is the number of cards left in the stack
+	StackSize int `json:"stackSize"`
+
+	// Discarded is the stack of cards that have been discarded
+	Discarded []Card `json:"discarded"`
+
+	// ColorPiles represents the current number for each color that has been played.
+	ColorPiles map[CardColor]CardNumber `json:"colorPiles"`
+
+	Hints    uint8 `json:"hints"`    // current number of hints available
+	Misplays uint8 `json:"misplays"` // number of misplays so far
+
+	// Players is the list of players, in the same order as in the GameState.
+	Players []SeatView `json:"players"`
+
+	Started       bool    `json:"started"`
+	CurrentPlayer int     `json:"currentPlayer"`
+	Turn          int     `json:"turn"`
+	EndTurn       int     `json:"endTurn"`
+	Outcome       Outcome `json:"outcome"`
+}
+
+// SeatView represents a single player as seen by the viewer of a View.
+type SeatView struct {
+	// ID is the id of the player
+	ID uuid.UUID `json:"id"`
+
+	// HandSize is the number of cards in the hand of the player
+	HandSize int `json:"handSize"`
+
+	// Hand is the hand of the player.
+	// For the viewer, this is nil.
+	Hand []Card `json:"hand"`
+
+	// Knowledge is what the player knows about their hand.
+	// This is public information, and is included for every player.
+	Knowledge []Knowledge `json:"knowledge"`
+}
+
+// ErrUnknownPlayer is returned when a player is not part of a game.
+var ErrUnknownPlayer = errors.New("GameState: Player is not part of this game")
+
+// PlayerView returns a View of this game for the player with the provided id.
+// When no such player exists, returns ErrUnknownPlayer.
+func (state *GameState) PlayerView(id uuid.UUID) (*View, error) {
+	if state.findPlayer(id) == nil {
+		return nil, ErrUnknownPlayer
+	}
+
+	view := &View{
+		Viewer: id,
+		Mode:   state.Mode,
+
+		Options: *state.rules().Clone(),
+
+		StackSize: len(state.Stack),
+		Discarded: append([]Card(nil), state.Discarded...),
+
+		ColorPiles: make(map[CardColor]CardNumber, len(state.ColorPiles)),
+
+		Hints:    state.Hints,
+		Misplays: state.Misplays,
+
+		Players: make([]SeatView, len(state.Players)),
+
+		Started:       state.Started,
+		CurrentPlayer: state.CurrentPlayer,
+		Turn:          state.Turn,
+		EndTurn:       state.EndTurn,
+		Outcome:       state.Outcome,
+	}
+
+	for color, number := range state.ColorPiles {
+		view.ColorPiles[color] = number
+	}
+
+	for i, p := range state.Players {
+		view.Players[i].ID = p.ID
+		view.Players[i].HandSize = len(p.Hand)
+		view.Players[i].Knowledge = append([]Knowledge(nil), p.Knowledge...)
+		if p.ID != id {
+			view.Players[i].Hand = append([]Card(nil), p.Hand...)
+		}
+	}
+
+	return view, nil
+}
+
+// Me returns the index of the viewer in Players.
+func (view *View) Me() int {
+	for i, p := range view.Players {
+		if p.ID == view.Viewer {
+			return i
+		}
+	}
+	return -1
+}
+
+// Score returns the current score of the game, see GameState.Score.
+func (view *View) Score() int {
+	return score(view.ColorPiles, view.Outcome, &view.Options)
+}
+
+// LegalMoves returns all moves the viewer may currently make, in the same order as GameState.LegalMoves.
+// When it is not the turn of the viewer, or the game is not in progress, returns nil.
+func (view *View) LegalMoves() (moves []Move) {
+	me := view.Me()
+	if !view.Started || view.Outcome != OutcomeNone || me != view.CurrentPlayer {
+		return nil
+	}
+
+	for i := 0; i < view.Players[me].HandSize; i++ {
+		moves = append(moves, Move{Kind: MovePlay, ID: view.Viewer, Index: i})
+	}
+	if view.Hints < view.Options.MaxHints {
+		for i := 0; i < view.Players[me].HandSize; i++ {
+			moves = append(moves, Move{Kind: MoveDiscard, ID: view.Viewer, Index: i})
+		}
+	}
+	if view.Hints == 0 {
+		return moves
+	}
+
+	hints := make([]Hint, 0, len(validColors)+len(validNumbers))
+	for _, color := range validColors {
+		hints = append(hints, color.Hint())
+	}
+	for _, number := range validNumbers {
+		hints = append(hints, number.Hint())
+	}
+
+	for i, p := range view.Players {
+		if i == me {
+			continue
+		}
+		for _, hint := range hints {
+			if !hint.Legal(view.Mode) || !(view.Options.EmptyHints || touchesAny(hint, p.Hand, view.Mode)) {
+				continue
+			}
+			moves = append(moves, Move{Kind: MoveHint, ID: view.Viewer, Hint: hint, ToPlayerID: p.ID})
+		}
+	}
+	return moves
+}
+
+// touchesAny checks if hint touches any of the provided cards.
+func touchesAny(hint Hint, cards []Card, mode GameMode) bool {
+	for _, c := range cards {
+		if hint.Matches(c, mode) {
+			return true
+		}
+	}
+	return false
+}
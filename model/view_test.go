@@ -0,0 +1,93 @@
+package model
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGameState_PlayerView(t *testing.T) {
+	b1 := Card{ColorBlue, NumberOne}
+	r2 := Card{ColorRed, NumberTwo}
+	g3 := Card{ColorGreen, NumberThree}
+
+	state := newTestState([]Card{g3, g3}, []Card{b1, r2}, []Card{r2, g3, b1})
+	state.Discarded = []Card{g3}
+	state.ColorPiles[ColorBlue] = NumberOne
+
+	view, err := state.PlayerView(testPlayerIDs[0])
+	if err != nil {
+		t.Fatalf("GameState.PlayerView() error = %v", err)
+	}
+
+	unknown := NewKnowledge(ModeFiveColor)
+	wantPlayers := []SeatView{
+		{ID: testPlayerIDs[0], HandSize: 2, Knowledge: []Knowledge{unknown, unknown}},
+		{ID: testPlayerIDs[1], HandSize: 3, Hand: []Card{r2, g3, b1}, Knowledge: []Knowledge{unknown, unknown, unknown}},
+	}
+	if !reflect.DeepEqual(view.Players, wantPlayers) {
+		t.Errorf("View.Players = %v, want %v", view.Players, wantPlayers)
+	}
+	if view.StackSize != 2 {
+		t.Errorf("View.StackSize = %v, want 2", view.StackSize)
+	}
+	if view.Me() != 0 {
+		t.Errorf("View.Me() = %v, want 0", view.Me())
+	}
+	if view.Score() != 1 {
+		t.Errorf("View.Score() = %v, want 1", view.Score())
+	}
+
+	// the view must be a copy
+	view.Players[1].Hand[0] = b1
+	view.Discarded[0] = b1
+	view.ColorPiles[ColorBlue] = NumberFive
+	if state.Players[1].Hand[0] != r2 || state.Discarded[0] != g3 || state.ColorPiles[ColorBlue] != NumberOne {
+		t.Error("modifying the View modified the GameState")
+	}
+
+	if _, err := state.PlayerView(uuid.Nil); err != ErrUnknownPlayer {
+		t.Errorf("GameState.PlayerView() error = %v, want %v", err, ErrUnknownPlayer)
+	}
+}
+
+func TestView_LegalMoves(t *testing.T) {
+	for _, mode := range []GameMode{ModeFiveColor, ModeRainbow, ModeDarkRainbow} {
+		state := &GameState{Mode: mode}
+		for i := 0; i < 3; i++ {
+			if _, err := state.AddPlayer(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := state.Start(42); err != nil {
+			t.Fatal(err)
+		}
+
+		random := rand.New(rand.NewSource(42))
+		for !state.Over() {
+			want := state.LegalMoves()
+			for i, p := range state.Players {
+				view, err := state.PlayerView(p.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := view.LegalMoves()
+				if i != state.CurrentPlayer {
+					if got != nil {
+						t.Errorf("%s turn %d: View.LegalMoves() = %v for other player, want nil", mode, state.Turn, got)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("%s turn %d: View.LegalMoves() = %v, want %v", mode, state.Turn, got, want)
+				}
+			}
+
+			if err := state.Apply(want[random.Intn(len(want))]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
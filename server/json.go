@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/lobby"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// ErrorResponse is the body of a response to a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// statusCodes maps errors to the status codes they are reported with.
+// Errors not in this map are reported as http.StatusInternalServerError.
+var statusCodes = map[error]int{
+	ErrNotFound:         http.StatusNotFound,
+	ErrMethodNotAllowed: http.StatusMethodNotAllowed,
+	ErrBadRequest:       http.StatusBadRequest,
+	ErrUnauthorized:     http.StatusUnauthorized,
+	ErrForbidden:        http.StatusForbidden,
+
+	lobby.ErrTableNotFound: http.StatusNotFound,
+
+	model.ErrModeInvalid:    http.StatusBadRequest,
+	model.ErrInvalidOptions: http.StatusBadRequest,
+	model.ErrUnknownPlayer:  http.StatusForbidden,
+
+	model.ErrGameStarted:        http.StatusConflict,
+	model.ErrInvalidPlayerCount: http.StatusConflict,
+	model.ErrGameNotStarted:     http.StatusConflict,
+	model.ErrGameOver:           http.StatusConflict,
+	model.ErrNotYourTurn:        http.StatusConflict,
+
+	model.ErrInvalidMoveKind:   http.StatusUnprocessableEntity,
+	model.ErrInvalidIndex:      http.StatusUnprocessableEntity,
+	model.ErrNoHints:           http.StatusUnprocessableEntity,
+	model.ErrMaxHints:          http.StatusUnprocessableEntity,
+	model.ErrIllegalHint:       http.StatusUnprocessableEntity,
+	model.ErrInvalidHintTarget: http.StatusUnprocessableEntity,
+	model.ErrEmptyHint:         http.StatusUnprocessableEntity,
+}
+
+// StatusCode returns the status code an error is reported with.
+func StatusCode(err error) int {
+	if code, ok := statusCodes[errors.Cause(err)]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError writes err to w.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, StatusCode(err), ErrorResponse{Error: err.Error()})
+}
+
+// writeJSON writes value to w as JSON.
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(value)
+}
+
+// readJSON reads the body of r as JSON into value.
+// When the body can not be decoded, returns ErrBadRequest.
+func readJSON(r *http.Request, value interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		return errors.Wrap(ErrBadRequest, err.Error())
+	}
+	return nil
+}
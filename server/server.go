@@ -0,0 +1,344 @@
+// Package server implements an HTTP JSON API for hosting games of Hanabi.
+//
+// Games are created, joined and played using the following endpoints:
+//
+//	GET  /games                 list all games, see lobby.Info
+//	POST /games                 create a new game, body: {"mode": GameMode, "options": RuleOptions}
+//	POST /games/{id}/join       join a game, returns {"player": id, "token": token}
+//	POST /games/{id}/leave      leave a game that has not yet started, the token becomes invalid
+//	POST /games/{id}/start      start a game, body: {"seed": int} (optional)
+//	GET  /games/{id}/view       get the View of the caller
+//	POST /games/{id}/moves      make a Move as the caller
+//	GET  /games/{id}/history    get the History of the game as seen by the caller
+//	GET  /games/{id}/ws         play the game live using a WebSocket, see Message
+//
+// All endpoints except listing, creating and joining games require the token returned when joining in an "Authorization: Bearer" header.
+// As browsers can not set headers for WebSockets, the token may also be passed using the "token" query parameter.
+// Tokens are secret, unlike the ids of players which are visible to all other players.
+//
+// Errors are returned as {"error": message} with an appropriate status code.
+//
+// Games are hosted by a lobby.Manager.
+// Games that are no longer used should be removed regularly using Collect.
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/actor"
+	"github.com/tkw1536/hanabi/lobby"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Server is an http.Handler that hosts games.
+type Server struct {
+	lobby *lobby.Manager
+
+	m      sync.Mutex
+	tokens map[string]seat
+}
+
+// seat identifies a player in a game
+type seat struct {
+	Game   uuid.UUID
+	Player uuid.UUID
+}
+
+// New creates a new Server without any games.
+func New() *Server {
+	return &Server{
+		lobby:  lobby.NewManager(),
+		tokens: make(map[string]seat),
+	}
+}
+
+// Close closes all games hosted by this server.
+func (server *Server) Close() {
+	server.lobby.Close()
+
+	server.m.Lock()
+	defer server.m.Unlock()
+
+	server.tokens = make(map[string]seat)
+}
+
+// Collect removes all games that have not been used for at least idle, see lobby.Manager.Collect.
+// The tokens of their players become invalid.
+func (server *Server) Collect(idle time.Duration) {
+	removed := make(map[uuid.UUID]struct{})
+	for _, id := range server.lobby.Collect(idle) {
+		removed[id] = struct{}{}
+	}
+
+	server.m.Lock()
+	defer server.m.Unlock()
+
+	for token, seat := range server.tokens {
+		if _, ok := removed[seat.Game]; ok {
+			delete(server.tokens, token)
+		}
+	}
+}
+
+// Errors returned by the server.
+var (
+	ErrNotFound         = errors.New("server: Not found")
+	ErrMethodNotAllowed = errors.New("server: Method not allowed")
+	ErrBadRequest       = errors.New("server: Unable to decode request")
+	ErrUnauthorized     = errors.New("server: Missing or invalid token")
+	ErrForbidden        = errors.New("server: Token does not belong to this game")
+)
+
+// ServeHTTP serves an API request.
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "games" || len(parts) > 3 {
+		writeError(w, ErrNotFound)
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			server.list(w, r)
+		case http.MethodPost:
+			server.create(w, r)
+		default:
+			writeError(w, ErrMethodNotAllowed)
+		}
+		return
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		writeError(w, ErrNotFound)
+		return
+	}
+	table, err := server.lobby.Get(id)
+	if err != nil || len(parts) != 3 {
+		writeError(w, ErrNotFound)
+		return
+	}
+
+	var handler func(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID)
+	var method string
+	switch parts[2] {
+	case "join":
+		method, handler = http.MethodPost, server.join
+	case "leave":
+		method, handler = http.MethodPost, server.leave
+	case "start":
+		method, handler = http.MethodPost, server.start
+	case "view":
+		method, handler = http.MethodGet, server.view
+	case "moves":
+		method, handler = http.MethodPost, server.move
+	case "history":
+		method, handler = http.MethodGet, server.history
+	case "ws":
+		method, handler = http.MethodGet, server.websocket
+	default:
+		writeError(w, ErrNotFound)
+		return
+	}
+	if r.Method != method {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+
+	var player uuid.UUID
+	if parts[2] != "join" {
+		if player, err = server.authenticate(r, id); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	handler(w, r, id, table.Game, player)
+}
+
+// authenticate returns the player in game identified by the token of r.
+func (server *Server) authenticate(r *http.Request, game uuid.UUID) (uuid.UUID, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	if token == "" {
+		return uuid.Nil, ErrUnauthorized
+	}
+
+	server.m.Lock()
+	defer server.m.Unlock()
+
+	seat, ok := server.tokens[token]
+	switch {
+	case !ok:
+		return uuid.Nil, ErrUnauthorized
+	case seat.Game != game:
+		return uuid.Nil, ErrForbidden
+	}
+	return seat.Player, nil
+}
+
+// newToken generates a new random token
+func newToken() (string, error) {
+	var token [32]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", errors.Wrap(err, "server: Unable to generate token")
+	}
+	return hex.EncodeToString(token[:]), nil
+}
+
+// CreateRequest is the body of a request to create a game.
+type CreateRequest struct {
+	Mode model.GameMode `json:"mode"`
+
+	// Options are the rules of the game.
+	// When omitted, lobby.DefaultOptions are used, which allow between 2 and 5 players.
+	Options *model.RuleOptions `json:"options,omitempty"`
+}
+
+// CreateResponse is the response to creating a game.
+type CreateResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (server *Server) list(w http.ResponseWriter, r *http.Request) {
+	infos, err := server.lobby.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (server *Server) create(w http.ResponseWriter, r *http.Request) {
+	var request CreateRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	table, err := server.lobby.Create(request.Mode, request.Options)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, CreateResponse{ID: table.ID})
+}
+
+// JoinResponse is the response to joining a game.
+type JoinResponse struct {
+	// Player is the id of the new player
+	Player uuid.UUID `json:"player"`
+
+	// Token is the secret token used to authenticate as the player
+	Token string `json:"token"`
+}
+
+func (server *Server) join(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, _ uuid.UUID) {
+	token, err := newToken()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	player, err := server.lobby.Join(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	server.m.Lock()
+	server.tokens[token] = seat{Game: id, Player: player}
+	server.m.Unlock()
+
+	writeJSON(w, http.StatusCreated, JoinResponse{Player: player, Token: token})
+}
+
+func (server *Server) leave(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID) {
+	if err := server.lobby.Leave(r.Context(), id, player); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	server.m.Lock()
+	for token, seat := range server.tokens {
+		if seat.Player == player {
+			delete(server.tokens, token)
+		}
+	}
+	server.m.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StartRequest is the body of a request to start a game.
+type StartRequest struct {
+	// Seed is passed to GameState.Start.
+	// When 0, a random game is started.
+	Seed int64 `json:"seed"`
+}
+
+func (server *Server) start(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID) {
+	var request StartRequest
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &request); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	if err := server.lobby.Start(r.Context(), id, request.Seed); err != nil {
+		writeError(w, err)
+		return
+	}
+	server.view(w, r, id, game, player)
+}
+
+func (server *Server) view(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID) {
+	view, err := game.View(r.Context(), player)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+func (server *Server) move(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID) {
+	var move model.Move
+	if err := readJSON(r, &move); err != nil {
+		writeError(w, err)
+		return
+	}
+	move.ID = player
+
+	if err := game.Apply(r.Context(), move); err != nil {
+		writeError(w, err)
+		return
+	}
+	server.view(w, r, id, game, player)
+}
+
+func (server *Server) history(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID) {
+	var history []model.Event
+	err := game.Do(r.Context(), func(state *model.GameState) (err error) {
+		history, err = state.PlayerHistory(player)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if history == nil {
+		history = []model.Event{}
+	}
+	writeJSON(w, http.StatusOK, history)
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/tkw1536/hanabi/lobby"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// testClient makes requests to a test server
+type testClient struct {
+	t       *testing.T
+	handler *Server
+	server  *httptest.Server
+}
+
+func newTestClient(t *testing.T) *testClient {
+	handler := New()
+	server := httptest.NewServer(handler)
+	t.Cleanup(func() {
+		server.Close()
+		handler.Close()
+	})
+	return &testClient{t: t, handler: handler, server: server}
+}
+
+// do makes a request and decodes the response into result, if not nil.
+// It returns the status code of the response.
+func (c *testClient) do(method, path, token string, body interface{}, result interface{}) int {
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, c.server.URL+path, &reader)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := c.server.Client().Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if result != nil && res.StatusCode < 300 {
+		if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+			c.t.Fatalf("%s %s: unable to decode response: %v", method, path, err)
+		}
+	}
+	return res.StatusCode
+}
+
+// create creates a new game and returns its path
+func (c *testClient) create(mode model.GameMode) string {
+	var created CreateResponse
+	if code := c.do(http.MethodPost, "/games", "", CreateRequest{Mode: mode}, &created); code != http.StatusCreated {
+		c.t.Fatalf("POST /games = %v, want %v", code, http.StatusCreated)
+	}
+	return "/games/" + created.ID.String()
+}
+
+// join joins the game at path
+func (c *testClient) join(path string) JoinResponse {
+	var joined JoinResponse
+	if code := c.do(http.MethodPost, path+"/join", "", nil, &joined); code != http.StatusCreated {
+		c.t.Fatalf("POST %s/join = %v, want %v", path, code, http.StatusCreated)
+	}
+	return joined
+}
+
+func TestServer(t *testing.T) {
+	c := newTestClient(t)
+
+	game := c.create(model.ModeFiveColor)
+	alice := c.join(game)
+	bob := c.join(game)
+	if alice.Token == bob.Token || alice.Token == alice.Player.String() {
+		t.Error("tokens are not secret")
+	}
+
+	var view model.View
+	if code := c.do(http.MethodPost, game+"/start", alice.Token, StartRequest{Seed: 1}, &view); code != http.StatusOK {
+		t.Fatalf("POST start = %v, want %v", code, http.StatusOK)
+	}
+	if !view.Started || view.Viewer != alice.Player {
+		t.Errorf("start returned %v", view)
+	}
+	if view.Players[0].Hand != nil || view.Players[1].Hand == nil {
+		t.Error("start returned unredacted view")
+	}
+
+	move := model.Move{Kind: model.MoveHint, Hint: view.Players[1].Hand[0].Number.Hint(), ToPlayerID: bob.Player}
+	if code := c.do(http.MethodPost, game+"/moves", alice.Token, move, &view); code != http.StatusOK {
+		t.Fatalf("POST moves = %v, want %v", code, http.StatusOK)
+	}
+	if view.Hints != model.MaxHints-1 || view.CurrentPlayer != 1 {
+		t.Errorf("move was not applied: %v", view)
+	}
+
+	if code := c.do(http.MethodGet, game+"/view", bob.Token, nil, &view); code != http.StatusOK {
+		t.Fatalf("GET view = %v, want %v", code, http.StatusOK)
+	}
+	if view.Viewer != bob.Player || view.Players[1].Hand != nil {
+		t.Errorf("GET view returned view for wrong player")
+	}
+
+	var history []model.Event
+	if code := c.do(http.MethodGet, game+"/history", bob.Token, nil, &history); code != http.StatusOK {
+		t.Fatalf("GET history = %v, want %v", code, http.StatusOK)
+	}
+	for _, event := range history {
+		if event.Kind == model.EventDraw && event.Player == bob.Player && event.Card != (model.Card{}) {
+			t.Error("GET history returned own drawn card")
+		}
+	}
+	if last := history[len(history)-2]; last.Kind != model.EventHint || last.Player != alice.Player {
+		t.Errorf("GET history does not contain hint, got %v", last)
+	}
+}
+
+func TestServer_Lobby(t *testing.T) {
+	c := newTestClient(t)
+
+	first := c.create(model.ModeSixColor)
+	second := c.create(model.ModeFiveColor)
+	alice := c.join(second)
+	bob := c.join(second)
+
+	var infos []lobby.Info
+	if code := c.do(http.MethodGet, "/games", "", nil, &infos); code != http.StatusOK {
+		t.Fatalf("GET /games = %v, want %v", code, http.StatusOK)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("GET /games returned %d games, want 2", len(infos))
+	}
+	players := make(map[string]int)
+	for _, info := range infos {
+		players["/games/"+info.ID.String()] = info.Players
+	}
+	if players[first] != 0 || players[second] != 2 {
+		t.Errorf("GET /games returned players %v", players)
+	}
+
+	if code := c.do(http.MethodPost, second+"/leave", bob.Token, nil, nil); code != http.StatusNoContent {
+		t.Fatalf("POST leave = %v, want %v", code, http.StatusNoContent)
+	}
+	if code := c.do(http.MethodGet, second+"/view", bob.Token, nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("GET view after leaving = %v, want %v", code, http.StatusUnauthorized)
+	}
+
+	var view model.View
+	if code := c.do(http.MethodGet, second+"/view", alice.Token, nil, &view); code != http.StatusOK {
+		t.Fatalf("GET view = %v, want %v", code, http.StatusOK)
+	}
+	if len(view.Players) != 1 || view.Players[0].ID != alice.Player {
+		t.Errorf("GET view after leaving returned players %v", view.Players)
+	}
+}
+
+func TestServer_Collect(t *testing.T) {
+	c := newTestClient(t)
+
+	game := c.create(model.ModeFiveColor)
+	alice := c.join(game)
+
+	c.handler.Collect(0)
+	if len(c.handler.tokens) != 0 {
+		t.Errorf("Collect() kept %d tokens", len(c.handler.tokens))
+	}
+	if code := c.do(http.MethodGet, game+"/view", alice.Token, nil, nil); code != http.StatusNotFound {
+		t.Errorf("GET view of collected game = %v, want %v", code, http.StatusNotFound)
+	}
+	if code := c.do(http.MethodPost, game+"/join", "", nil, nil); code != http.StatusNotFound {
+		t.Errorf("POST join of collected game = %v, want %v", code, http.StatusNotFound)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	c := newTestClient(t)
+
+	started := c.create(model.ModeRainbow)
+	alice := c.join(started)
+	bob := c.join(started)
+	if code := c.do(http.MethodPost, started+"/start", alice.Token, nil, nil); code != http.StatusOK {
+		t.Fatalf("POST start = %v, want %v", code, http.StatusOK)
+	}
+
+	waiting := c.create(model.ModeFiveColor)
+	carol := c.join(waiting)
+
+	full := c.create(model.ModeFiveColor)
+	for i := 0; i < lobby.MaxPlayers; i++ {
+		c.join(full)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   interface{}
+		want   int
+	}{
+		{"unknown route", http.MethodGet, "/", "", nil, http.StatusNotFound},
+		{"unknown game", http.MethodGet, "/games/" + uuid.New().String() + "/view", alice.Token, nil, http.StatusNotFound},
+		{"invalid game id", http.MethodGet, "/games/invalid/view", alice.Token, nil, http.StatusNotFound},
+		{"wrong method", http.MethodDelete, "/games", "", nil, http.StatusMethodNotAllowed},
+		{"invalid mode", http.MethodPost, "/games", "", CreateRequest{Mode: "invalid"}, http.StatusBadRequest},
+		{"invalid options", http.MethodPost, "/games", "", CreateRequest{Mode: model.ModeFiveColor, Options: &model.RuleOptions{}}, http.StatusBadRequest},
+		{"invalid body", http.MethodPost, "/games", "", "not a request", http.StatusBadRequest},
+
+		{"missing token", http.MethodGet, started + "/view", "", nil, http.StatusUnauthorized},
+		{"invalid token", http.MethodGet, started + "/view", "invalid", nil, http.StatusUnauthorized},
+		{"token of other game", http.MethodGet, started + "/view", carol.Token, nil, http.StatusForbidden},
+
+		{"join started game", http.MethodPost, started + "/join", "", nil, http.StatusConflict},
+		{"join full game", http.MethodPost, full + "/join", "", nil, http.StatusConflict},
+		{"start with too few players", http.MethodPost, waiting + "/start", carol.Token, nil, http.StatusConflict},
+		{"move before start", http.MethodPost, waiting + "/moves", carol.Token, model.Move{Kind: model.MoveDiscard}, http.StatusConflict},
+		{"start started game", http.MethodPost, started + "/start", alice.Token, nil, http.StatusConflict},
+		{"leave started game", http.MethodPost, started + "/leave", alice.Token, nil, http.StatusConflict},
+		{"not your turn", http.MethodPost, started + "/moves", bob.Token, model.Move{Kind: model.MovePlay}, http.StatusConflict},
+
+		{"invalid move", http.MethodPost, started + "/moves", alice.Token, model.Move{Kind: model.MovePlay, Index: 10}, http.StatusUnprocessableEntity},
+		{"illegal hint", http.MethodPost, started + "/moves", alice.Token, model.Move{Kind: model.MoveHint, Hint: model.ColorRainbow.Hint()}, http.StatusUnprocessableEntity},
+		{"discard with max hints", http.MethodPost, started + "/moves", alice.Token, model.Move{Kind: model.MoveDiscard}, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := c.do(tt.method, tt.path, tt.token, tt.body, nil); code != tt.want {
+				t.Errorf("%s %s = %v, want %v", tt.method, tt.path, code, tt.want)
+			}
+		})
+	}
+}
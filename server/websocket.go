@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/actor"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// MessageType is the type of a message sent over a WebSocket.
+type MessageType string
+
+// The different types of messages
+const (
+	// MessageView is sent by the server, and contains the current View of the player.
+	// It is sent once after connecting, and after every move that has been made afterwards.
+	MessageView MessageType = "view"
+
+	// MessageEvent is sent by the server, and contains an Event that happened in the game along with its index in the history.
+	// Events are redacted for the player.
+	MessageEvent MessageType = "event"
+
+	// MessageError is sent by the server when a message of the client could not be handled.
+	// It contains the error and the status code the error would be returned with by the HTTP API.
+	MessageError MessageType = "error"
+
+	// MessageMove is sent by the client, and contains a Move to be made by the player.
+	// The ID of the move is ignored and set to the id of the player.
+	MessageMove MessageType = "move"
+)
+
+// Message is a single message sent over a WebSocket, encoded as JSON.
+// Only the fields relevant to the type of message are set.
+//
+// For example, a client gives a hint by sending:
+//
+//	{"type": "move", "move": {"kind": "hint", "hint": {"color": "Red"}, "toPlayerId": "..."}}
+//
+// And then receives:
+//
+//	{"type": "event", "index": 42, "event": {"kind": "hint", ...}}
+//	{"type": "event", "index": 43, "event": {"kind": "turn", ...}}
+//	{"type": "view", "view": {...}}
+//
+// A player can connect to a game any number of times using the same token.
+// When reconnecting after a connection dropped, the "since" query parameter can be set to the index of the first event not yet received.
+// Only events starting at that index are then sent.
+type Message struct {
+	Type MessageType `json:"type"`
+
+	View *model.View `json:"view,omitempty"`
+
+	Event *model.Event `json:"event,omitempty"`
+	Index int          `json:"index"` // only meaningful for events
+
+	Move *model.Move `json:"move,omitempty"`
+
+	Error  string `json:"error,omitempty"`
+	Status int    `json:"status,omitempty"`
+}
+
+// maxMessageSize is the maximum size of a message sent by a client.
+// Larger messages close the connection.
+const maxMessageSize = 1 << 12
+
+// upgrader upgrades HTTP connections to WebSockets
+var upgrader = websocket.Upgrader{}
+
+// connection is a WebSocket connection of a single player to a game
+type connection struct {
+	conn *websocket.Conn
+	m    sync.Mutex // held while writing
+
+	game   *actor.Game
+	player uuid.UUID
+
+	// touch marks the game as used, see lobby.Manager.Touch
+	touch func()
+}
+
+// send sends message to the client.
+func (c *connection) send(message Message) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.conn.WriteJSON(message)
+}
+
+// sendView sends the current view of the player to the client.
+func (c *connection) sendView(ctx context.Context) error {
+	view, err := c.game.View(ctx, c.player)
+	if err != nil {
+		return err
+	}
+	return c.send(Message{Type: MessageView, View: view})
+}
+
+// sendError sends err to the client.
+func (c *connection) sendError(err error) error {
+	return c.send(Message{Type: MessageError, Error: err.Error(), Status: StatusCode(err)})
+}
+
+// receive handles messages sent by the client until the connection is closed.
+func (c *connection) receive(ctx context.Context) {
+	c.conn.SetReadLimit(maxMessageSize)
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.touch()
+
+		var message Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			c.sendError(errors.Wrap(ErrBadRequest, err.Error()))
+			continue
+		}
+
+		switch {
+		case message.Type != MessageMove || message.Move == nil:
+			err = errors.Wrap(ErrBadRequest, "Expected a move")
+		default:
+			message.Move.ID = c.player
+			err = c.game.Apply(ctx, *message.Move)
+		}
+		if err != nil {
+			c.sendError(err)
+		}
+	}
+}
+
+func (server *Server) websocket(w http.ResponseWriter, r *http.Request, id uuid.UUID, game *actor.Game, player uuid.UUID) {
+	since, _ := strconv.Atoi(r.URL.Query().Get("since"))
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // upgrader already replied with an error
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// games played using WebSockets do not call lobby.Manager.Get, so they are marked as used manually.
+	c := &connection{conn: conn, game: game, player: player, touch: func() { server.lobby.Touch(id) }}
+
+	// events before this index are already part of the initial view
+	var seen int
+	err = game.Do(ctx, func(state *model.GameState) error {
+		seen = len(state.History)
+		return nil
+	})
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+
+	subscription, err := game.Subscribe(ctx, player)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+	defer subscription.Close()
+
+	if err := c.sendView(ctx); err != nil {
+		return
+	}
+
+	go func() {
+		defer cancel()
+		c.receive(ctx)
+	}()
+
+	index := 0
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			c.touch()
+			index++
+			if index-1 < since {
+				continue
+			}
+
+			if err := c.send(Message{Type: MessageEvent, Event: &event, Index: index - 1}); err != nil {
+				return
+			}
+			if index > seen && (event.Kind == model.EventTurn || event.Kind == model.EventGameOver) {
+				if err := c.sendView(ctx); err != nil {
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
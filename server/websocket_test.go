@@ -0,0 +1,203 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// dial connects to the websocket of the game at path
+func (c *testClient) dial(path, token string, since int) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(c.server.URL, "http") + path + "/ws?token=" + token + "&since=" + strconv.Itoa(since)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		c.t.Fatalf("Dial() error = %v", err)
+	}
+	c.t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// receive receives the next message from conn
+func receive(t *testing.T, conn *websocket.Conn) (message Message) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	return message
+}
+
+// receiveUntil receives messages from conn until one of type kind has been received
+func receiveUntil(t *testing.T, conn *websocket.Conn, kind MessageType) (messages []Message) {
+	for {
+		message := receive(t, conn)
+		messages = append(messages, message)
+		if message.Type == kind {
+			return messages
+		}
+	}
+}
+
+func TestServer_WebSocket(t *testing.T) {
+	c := newTestClient(t)
+
+	game := c.create(model.ModeFiveColor)
+	alice := c.join(game)
+	bob := c.join(game)
+
+	aliceConn := c.dial(game, alice.Token, 0)
+	if message := receive(t, aliceConn); message.Type != MessageView || message.View.Started {
+		t.Fatalf("first message = %v, want view of waiting game", message)
+	}
+
+	// starting the game pushes the deal and the new view
+	if code := c.do(http.MethodPost, game+"/start", alice.Token, StartRequest{Seed: 1}, nil); code != http.StatusOK {
+		t.Fatalf("POST start = %v, want %v", code, http.StatusOK)
+	}
+	messages := receiveUntil(t, aliceConn, MessageView)
+	for i, message := range messages[:len(messages)-1] {
+		if message.Type != MessageEvent || message.Index != i {
+			t.Fatalf("message %d = %v, want event %d", i, message, i)
+		}
+		if message.Event.Kind == model.EventDraw && message.Event.Player == alice.Player && message.Event.Card != (model.Card{}) {
+			t.Error("own drawn card was sent")
+		}
+	}
+	view := messages[len(messages)-1].View
+	if !view.Started {
+		t.Fatal("view after start is not started")
+	}
+	dealt := len(messages) - 1
+
+	bobConn := c.dial(game, bob.Token, dealt)
+	if message := receive(t, bobConn); message.Type != MessageView || message.View.Viewer != bob.Player {
+		t.Fatalf("first message = %v, want view of bob", message)
+	}
+
+	// illegal moves are reported
+	if err := aliceConn.WriteJSON(Message{Type: MessageMove, Move: &model.Move{Kind: model.MoveDiscard}}); err != nil {
+		t.Fatal(err)
+	}
+	if message := receive(t, aliceConn); message.Type != MessageError || message.Status != http.StatusUnprocessableEntity {
+		t.Errorf("message = %v, want error", message)
+	}
+	if err := aliceConn.WriteMessage(websocket.TextMessage, []byte("not json")); err != nil {
+		t.Fatal(err)
+	}
+	if message := receive(t, aliceConn); message.Type != MessageError || message.Status != http.StatusBadRequest {
+		t.Errorf("message = %v, want error", message)
+	}
+
+	// moves are pushed to all players
+	move := model.Move{Kind: model.MoveHint, Hint: view.Players[1].Hand[0].Number.Hint(), ToPlayerID: bob.Player}
+	if err := aliceConn.WriteJSON(Message{Type: MessageMove, Move: &move}); err != nil {
+		t.Fatal(err)
+	}
+	for _, conn := range []*websocket.Conn{aliceConn, bobConn} {
+		messages := receiveUntil(t, conn, MessageView)
+		if len(messages) != 3 || messages[0].Event.Kind != model.EventHint || messages[0].Index != dealt {
+			t.Errorf("messages = %v, want hint, turn and view", messages)
+		}
+		if view := messages[2].View; view.CurrentPlayer != 1 || view.Hints != model.MaxHints-1 {
+			t.Errorf("view = %v, want hint applied", view)
+		}
+	}
+
+	// reconnecting as alice without since replays all events, but only sends the current view once
+	aliceConn.Close()
+	aliceConn = c.dial(game, alice.Token, 0)
+	if message := receive(t, aliceConn); message.Type != MessageView || message.View.Turn != 1 {
+		t.Fatalf("first message = %v, want current view", message)
+	}
+	for i := 0; i < dealt+2; i++ {
+		if message := receive(t, aliceConn); message.Type != MessageEvent || message.Index != i {
+			t.Fatalf("message = %v, want event %d", message, i)
+		}
+	}
+}
+
+func TestServer_WebSocket_Unauthorized(t *testing.T) {
+	c := newTestClient(t)
+	game := c.create(model.ModeFiveColor)
+
+	url := "ws" + strings.TrimPrefix(c.server.URL, "http") + game + "/ws?token=invalid"
+	_, res, err := websocket.DefaultDialer.Dial(url, nil)
+	if err == nil {
+		t.Fatal("Dial() error = nil")
+	}
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %v, want %v", res.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestServer_WebSocket_Messages(t *testing.T) {
+	c := newTestClient(t)
+
+	game := c.create(model.ModeFiveColor)
+	alice := c.join(game)
+	c.join(game)
+	if code := c.do(http.MethodPost, game+"/start", alice.Token, StartRequest{Seed: 1}, nil); code != http.StatusOK {
+		t.Fatalf("POST start = %v, want %v", code, http.StatusOK)
+	}
+
+	conn := c.dial(game, alice.Token, 0)
+	receive(t, conn) // current view
+
+	// the first event includes its index
+	var first map[string]interface{}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.ReadJSON(&first); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if index, ok := first["index"]; first["type"] != string(MessageEvent) || !ok || index != 0.0 {
+		t.Errorf("first event = %v, want index 0", first)
+	}
+
+	// messages that are too large close the connection
+	if err := conn.WriteMessage(websocket.TextMessage, make([]byte, maxMessageSize+1)); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue // remaining events
+		}
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			t.Error("connection was not closed")
+		}
+		return
+	}
+}
+
+func TestServer_WebSocket_Collect(t *testing.T) {
+	c := newTestClient(t)
+
+	game := c.create(model.ModeFiveColor)
+	alice := c.join(game)
+	bob := c.join(game)
+	if code := c.do(http.MethodPost, game+"/start", alice.Token, StartRequest{Seed: 1}, nil); code != http.StatusOK {
+		t.Fatalf("POST start = %v, want %v", code, http.StatusOK)
+	}
+
+	conn := c.dial(game, alice.Token, 0)
+	view := receiveUntil(t, conn, MessageView)[0].View
+	time.Sleep(200 * time.Millisecond)
+
+	// a move made using the WebSocket marks the game as used
+	move := model.Move{Kind: model.MoveHint, Hint: view.Players[1].Hand[0].Number.Hint(), ToPlayerID: bob.Player}
+	if err := conn.WriteJSON(Message{Type: MessageMove, Move: &move}); err != nil {
+		t.Fatal(err)
+	}
+	receiveUntil(t, conn, MessageView)
+
+	c.handler.Collect(100 * time.Millisecond)
+	if code := c.do(http.MethodGet, game+"/view", alice.Token, nil, nil); code != http.StatusOK {
+		t.Errorf("GET view = %v, want %v", code, http.StatusOK)
+	}
+}
@@ -0,0 +1,124 @@
+// Package sim runs many games of Hanabi with Agents in parallel and computes statistics about their scores.
+package sim
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/agent"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Factory creates a new agent for the player at seat in the game with the provided seed.
+// Agents that use randomness should derive it from seed, to keep simulations reproducible.
+type Factory func(seed int64, seat int) agent.Agent
+
+// Simulator plays many games with agents created by a Factory.
+type Simulator struct {
+	// Modes are the GameModes to play games in
+	Modes []model.GameMode
+
+	// Options are the rules games are played with.
+	// When nil, uses model.DefaultRuleOptions.
+	Options *model.RuleOptions
+
+	// Players is the number of players in each game
+	Players int
+
+	// Games is the number of games played per GameMode.
+	// Games are played with the seeds Seed+1, ..., Seed+Games.
+	Games int
+	Seed  int64
+
+	// Workers is the number of games played in parallel.
+	// When 0, uses runtime.NumCPU().
+	Workers int
+
+	// NewAgent creates the agents to play the games with
+	NewAgent Factory
+
+	// Policy and MaxRetries are passed to the agent.Runner playing the games
+	Policy     agent.IllegalMovePolicy
+	MaxRetries int
+}
+
+// ErrInvalidSimulator is returned when running a Simulator that is not properly configured.
+var ErrInvalidSimulator = errors.New("Simulator: Invalid configuration")
+
+// job represents a single game to be played
+type job struct {
+	mode  int // index into Modes
+	index int // index of the game
+}
+
+// Run plays all games of this simulator and returns statistics for each GameMode, in the order of Modes.
+//
+// Results only depend on the configuration of the simulator and the agents, and not on the number of Workers.
+// When a game can not be played, returns the error of the first such game.
+func (s *Simulator) Run() ([]*Stats, error) {
+	if s.NewAgent == nil || s.Games < 0 || s.Seed < 0 || s.Workers < 0 {
+		return nil, ErrInvalidSimulator
+	}
+
+	workers := s.Workers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([][]*agent.Result, len(s.Modes))
+	errs := make([][]error, len(s.Modes))
+	for i := range s.Modes {
+		results[i] = make([]*agent.Result, s.Games)
+		errs[i] = make([]error, s.Games)
+	}
+
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j.mode][j.index], errs[j.mode][j.index] = s.play(s.Modes[j.mode], j.index)
+			}
+		}()
+	}
+
+	for mode := range s.Modes {
+		for index := 0; index < s.Games; index++ {
+			jobs <- job{mode: mode, index: index}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	stats := make([]*Stats, len(s.Modes))
+	for i, mode := range s.Modes {
+		for index, err := range errs[i] {
+			if err != nil {
+				return nil, errors.Wrapf(err, "Simulator: Game %d in mode %s failed", index, mode)
+			}
+		}
+		stats[i] = NewStats(mode, results[i])
+	}
+	return stats, nil
+}
+
+// play plays the game with the provided index.
+func (s *Simulator) play(mode model.GameMode, index int) (*agent.Result, error) {
+	seed := s.Seed + int64(index) + 1
+
+	agents := make([]agent.Agent, s.Players)
+	for seat := range agents {
+		agents[seat] = s.NewAgent(seed, seat)
+	}
+
+	runner := &agent.Runner{
+		Mode:       mode,
+		Options:    s.Options,
+		Policy:     s.Policy,
+		MaxRetries: s.MaxRetries,
+	}
+	return runner.Run(seed, agents...)
+}
@@ -0,0 +1,122 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/tkw1536/hanabi/agent"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// testAgent makes random plays, discards and hints
+type testAgent struct {
+	rand *rand.Rand
+}
+
+func newTestAgent(seed int64, seat int) agent.Agent {
+	return &testAgent{rand: rand.New(rand.NewSource(seed*10 + int64(seat)))}
+}
+
+func (a *testAgent) Observe(event model.Event) {}
+
+func (a *testAgent) Move(view *model.View) model.Move {
+	me := view.Players[view.Me()]
+	index := a.rand.Intn(me.HandSize)
+
+	choice := a.rand.Intn(3)
+	switch {
+	case choice == 0:
+		return model.Move{Kind: model.MovePlay, Index: index}
+	case choice == 1 && view.Hints > 0:
+		next := view.Players[(view.Me()+1)%len(view.Players)]
+		return model.Move{Kind: model.MoveHint, Hint: next.Hand[0].Number.Hint(), ToPlayerID: next.ID}
+	case view.Hints < view.Options.MaxHints:
+		return model.Move{Kind: model.MoveDiscard, Index: index}
+	}
+	return model.Move{Kind: model.MovePlay, Index: index}
+}
+
+func TestSimulator_Run(t *testing.T) {
+	run := func(workers int) []*Stats {
+		s := &Simulator{
+			Modes:    []model.GameMode{model.ModeFiveColor, model.ModeRainbow},
+			Players:  3,
+			Games:    50,
+			Workers:  workers,
+			NewAgent: newTestAgent,
+		}
+		stats, err := s.Run()
+		if err != nil {
+			t.Fatalf("Simulator.Run() error = %v", err)
+		}
+		return stats
+	}
+
+	want := run(1)
+	if len(want) != 2 {
+		t.Fatalf("len(Stats) = %v, want 2", len(want))
+	}
+	for _, stats := range want {
+		if len(stats.Scores) != 50 {
+			t.Errorf("len(Scores) = %v, want 50", len(stats.Scores))
+		}
+		if len(stats.Histogram) != len(stats.Mode.Suits())*5+1 {
+			t.Errorf("len(Histogram) = %v, want %v", len(stats.Histogram), len(stats.Mode.Suits())*5+1)
+		}
+	}
+	if want[0].Mode != model.ModeFiveColor || want[1].Mode != model.ModeRainbow {
+		t.Errorf("Stats are not in the order of Modes")
+	}
+
+	for _, workers := range []int{2, 7} {
+		if got := run(workers); !reflect.DeepEqual(got, want) {
+			t.Errorf("Simulator.Run() with %d workers differs from 1 worker", workers)
+		}
+	}
+}
+
+func TestSimulator_Run_Invalid(t *testing.T) {
+	s := &Simulator{Modes: []model.GameMode{model.ModeFiveColor}, Players: 2, Games: 1}
+	if _, err := s.Run(); err != ErrInvalidSimulator {
+		t.Errorf("Simulator.Run() error = %v, want %v", err, ErrInvalidSimulator)
+	}
+
+	s.NewAgent = newTestAgent
+	s.Players = 1
+	if _, err := s.Run(); err == nil {
+		t.Error("Simulator.Run() error = nil for invalid number of players")
+	}
+}
+
+func TestNewStats(t *testing.T) {
+	results := []*agent.Result{
+		{Score: 25, Outcome: model.OutcomePerfect},
+		{Score: 0, Outcome: model.OutcomeStrikeout},
+		{Score: 20, Outcome: model.OutcomeDeckout},
+		{Score: 15, Outcome: model.OutcomeDeckout},
+	}
+	stats := NewStats(model.ModeFiveColor, results)
+
+	if stats.Mean != 15 {
+		t.Errorf("Mean = %v, want 15", stats.Mean)
+	}
+	if stats.Median != 17.5 {
+		t.Errorf("Median = %v, want 17.5", stats.Median)
+	}
+	// sample standard deviation is sqrt(350/3), divided by sqrt(4)
+	if want := math.Sqrt(350.0/3) / 2; math.Abs(stats.StdErr-want) > 1e-9 {
+		t.Errorf("StdErr = %v, want %v", stats.StdErr, want)
+	}
+	if stats.PerfectRate != 0.25 || stats.StrikeoutRate != 0.25 {
+		t.Errorf("PerfectRate, StrikeoutRate = %v, %v, want 0.25, 0.25", stats.PerfectRate, stats.StrikeoutRate)
+	}
+	if stats.Histogram[25] != 1 || stats.Histogram[0] != 1 || stats.Histogram[1] != 0 {
+		t.Errorf("Histogram = %v", stats.Histogram)
+	}
+
+	if empty := NewStats(model.ModeFiveColor, nil); empty.Mean != 0 || len(empty.Histogram) != 26 {
+		t.Errorf("NewStats() of no results = %v", empty)
+	}
+}
@@ -0,0 +1,84 @@
+package sim
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tkw1536/hanabi/agent"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// Stats represents statistics about the games played in a single GameMode.
+type Stats struct {
+	Mode model.GameMode
+
+	// Scores are the scores of the individual games, in the order they were played.
+	Scores []int
+
+	Mean   float64
+	Median float64
+
+	// StdErr is the standard error of Mean
+	StdErr float64
+
+	// PerfectRate and StrikeoutRate are the fraction of games with OutcomePerfect and OutcomeStrikeout
+	PerfectRate   float64
+	StrikeoutRate float64
+
+	// Histogram counts how many games had each score.
+	// It has an entry for each score from 0 up to the perfect score of the mode.
+	Histogram []int
+}
+
+// NewStats computes statistics from the results of games played in mode.
+func NewStats(mode model.GameMode, results []*agent.Result) *Stats {
+	stats := &Stats{
+		Mode:      mode,
+		Scores:    make([]int, len(results)),
+		Histogram: make([]int, len(mode.Suits())*int(model.NumberFive)+1),
+	}
+	if len(results) == 0 {
+		return stats
+	}
+
+	var perfect, strikeout int
+	for i, result := range results {
+		stats.Scores[i] = result.Score
+		stats.Histogram[result.Score]++
+
+		switch result.Outcome {
+		case model.OutcomePerfect:
+			perfect++
+		case model.OutcomeStrikeout:
+			strikeout++
+		}
+	}
+
+	n := float64(len(results))
+	stats.PerfectRate = float64(perfect) / n
+	stats.StrikeoutRate = float64(strikeout) / n
+
+	var sum float64
+	for _, score := range stats.Scores {
+		sum += float64(score)
+	}
+	stats.Mean = sum / n
+
+	if len(results) > 1 {
+		var squares float64
+		for _, score := range stats.Scores {
+			squares += (float64(score) - stats.Mean) * (float64(score) - stats.Mean)
+		}
+		stats.StdErr = math.Sqrt(squares/(n-1)) / math.Sqrt(n)
+	}
+
+	sorted := append([]int(nil), stats.Scores...)
+	sort.Ints(sorted)
+	if middle := len(sorted) / 2; len(sorted)%2 == 1 {
+		stats.Median = float64(sorted[middle])
+	} else {
+		stats.Median = float64(sorted[middle-1]+sorted[middle]) / 2
+	}
+
+	return stats
+}
@@ -0,0 +1,272 @@
+package solver
+
+import (
+	"sort"
+
+	"github.com/tkw1536/hanabi/model"
+)
+
+// node is a position in a game, in a compact form suitable for searching.
+//
+// Cards are represented as 5*suit + number - 1, where suit is the index into the suits of the game.
+type node struct {
+	rules *rules
+
+	hands [][]byte
+	drawn int // number of cards drawn from stack
+
+	piles    []model.CardNumber
+	hints    int
+	misplays int
+
+	current int
+
+	// turnsLeft is the number of turns left once the stack is empty, and -1 before
+	turnsLeft int
+}
+
+// rules holds the parts of a game that are shared between nodes
+type rules struct {
+	mode    model.GameMode
+	options *model.RuleOptions
+	suits   []model.CardColor
+
+	// stack is the stack of the game, with the next card to be drawn first
+	stack []byte
+}
+
+// newNode creates a node representing state.
+func newNode(state *model.GameState) *node {
+	options := state.Options
+	if options == nil {
+		options = model.DefaultRuleOptions()
+	}
+
+	r := &rules{mode: state.Mode, options: options, suits: state.Mode.Suits()}
+	for i := len(state.Stack) - 1; i >= 0; i-- {
+		r.stack = append(r.stack, r.encode(state.Stack[i]))
+	}
+
+	n := &node{
+		rules:     r,
+		hands:     make([][]byte, len(state.Players)),
+		piles:     make([]model.CardNumber, len(r.suits)),
+		hints:     int(state.Hints),
+		misplays:  int(state.Misplays),
+		current:   state.CurrentPlayer,
+		turnsLeft: -1,
+	}
+	for i, p := range state.Players {
+		for _, c := range p.Hand {
+			n.hands[i] = append(n.hands[i], r.encode(c))
+		}
+	}
+	for i, color := range r.suits {
+		n.piles[i] = state.ColorPiles[color]
+	}
+	if state.EndTurn != 0 {
+		n.turnsLeft = state.EndTurn - state.Turn
+	}
+	if state.Over() {
+		n.turnsLeft = 0
+	}
+	return n
+}
+
+// encode encodes a card
+func (r *rules) encode(card model.Card) byte {
+	for i, color := range r.suits {
+		if color == card.Color {
+			return byte(5*i) + byte(card.Number) - 1
+		}
+	}
+	panic("rules.encode(): precondition failed: card not part of mode")
+}
+
+// decode decodes a card into the index of its suit and its number
+func decode(card byte) (suit int, number model.CardNumber) {
+	return int(card / 5), model.CardNumber(card%5 + 1)
+}
+
+// over checks if the game has ended in this position.
+func (n *node) over() bool {
+	if n.misplays >= int(n.rules.options.MaxMisplays) || n.turnsLeft == 0 {
+		return true
+	}
+	for _, pile := range n.piles {
+		if pile != model.NumberFive {
+			return false
+		}
+	}
+	return true
+}
+
+// score returns the score of this position, taking ScoreZeroOnStrikeout into account.
+func (n *node) score() (score int) {
+	if n.rules.options.ScoreZeroOnStrikeout && n.misplays >= int(n.rules.options.MaxMisplays) {
+		return 0
+	}
+	for _, pile := range n.piles {
+		score += int(pile)
+	}
+	return score
+}
+
+// bound returns an upper bound for the score that can be achieved from this position.
+func (n *node) bound() int {
+	available := make([]int, 5*len(n.piles))
+	for _, hand := range n.hands {
+		for _, c := range hand {
+			available[c]++
+		}
+	}
+	for _, c := range n.rules.stack[n.drawn:] {
+		available[c]++
+	}
+
+	score, plays := 0, 0
+	for suit, pile := range n.piles {
+		score += int(pile)
+		for number := int(pile); number < 5 && available[5*suit+number] > 0; number++ {
+			plays++
+		}
+	}
+
+	if n.turnsLeft >= 0 && plays > n.turnsLeft {
+		plays = n.turnsLeft
+	}
+	return score + plays
+}
+
+// playable checks if card can be played
+func (n *node) playable(card byte) bool {
+	suit, number := decode(card)
+	return n.piles[suit]+1 == number
+}
+
+// moves returns the moves to consider in this position, in the order they should be searched.
+// Moves that are equivalent to an earlier move are omitted.
+func (n *node) moves() (moves []move) {
+	hand := n.hands[n.current]
+	maxHints := int(n.rules.options.MaxHints)
+
+	// plays of playable cards, lowest numbers first
+	var plays []move
+	for i, c := range hand {
+		if n.playable(c) && firstIndex(hand, c) == i {
+			plays = append(plays, move{Kind: model.MovePlay, Index: i})
+		}
+	}
+	sort.SliceStable(plays, func(i, j int) bool { return hand[plays[i].Index]%5 < hand[plays[j].Index]%5 })
+	moves = append(moves, plays...)
+
+	// discards, useless cards first
+	if n.hints < maxHints {
+		var useful []move
+		for i, c := range hand {
+			if firstIndex(hand, c) != i {
+				continue
+			}
+			if n.useless(c) {
+				moves = append(moves, move{Kind: model.MoveDiscard, Index: i})
+			} else {
+				useful = append(useful, move{Kind: model.MoveDiscard, Index: i})
+			}
+		}
+		moves = append(moves, useful...)
+	}
+
+	if n.hints > 0 {
+		moves = append(moves, move{Kind: model.MoveHint})
+	}
+
+	// misplays can only be useful when no card can be discarded
+	if n.hints >= maxHints {
+		for i, c := range hand {
+			if !n.playable(c) && firstIndex(hand, c) == i {
+				moves = append(moves, move{Kind: model.MovePlay, Index: i})
+			}
+		}
+	}
+	return moves
+}
+
+// useless checks if card has already been played
+func (n *node) useless(card byte) bool {
+	suit, number := decode(card)
+	return number <= n.piles[suit]
+}
+
+// firstIndex returns the first index of card in hand
+func firstIndex(hand []byte, card byte) int {
+	for i, c := range hand {
+		if c == card {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply returns the position after making move m.
+func (n *node) apply(m move) *node {
+	child := *n
+	child.hands = append([][]byte(nil), n.hands...)
+	child.piles = append([]model.CardNumber(nil), n.piles...)
+
+	if m.Kind == model.MoveHint {
+		child.hints--
+	} else {
+		hand := n.hands[n.current]
+		card := hand[m.Index]
+
+		// remove the card and draw a new one
+		newHand := make([]byte, 0, len(hand))
+		newHand = append(newHand, hand[:m.Index]...)
+		newHand = append(newHand, hand[m.Index+1:]...)
+		if child.drawn < len(n.rules.stack) {
+			newHand = append(newHand, n.rules.stack[child.drawn])
+			child.drawn++
+		}
+		child.hands[n.current] = newHand
+
+		switch {
+		case m.Kind == model.MoveDiscard:
+			child.hints++
+		case n.playable(card):
+			suit, number := decode(card)
+			child.piles[suit] = number
+			if number == model.NumberFive && child.hints < int(n.rules.options.MaxHints) {
+				child.hints++
+			}
+		default:
+			child.misplays++
+		}
+	}
+
+	// advance the turn
+	if child.turnsLeft > 0 {
+		child.turnsLeft--
+	}
+	if child.turnsLeft < 0 && child.drawn == len(n.rules.stack) {
+		child.turnsLeft = len(n.hands)
+	}
+	child.current = (n.current + 1) % len(n.hands)
+	return &child
+}
+
+// key returns a key identifying this position.
+// Positions that only differ in the order of cards within hands have the same key.
+func (n *node) key() string {
+	key := make([]byte, 0, 5+len(n.piles)+len(n.hands)*7)
+	key = append(key, byte(n.current), byte(n.hints), byte(n.misplays), byte(n.drawn), byte(n.turnsLeft+1))
+	for _, pile := range n.piles {
+		key = append(key, byte(pile))
+	}
+	for _, hand := range n.hands {
+		sorted := append([]byte(nil), hand...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		key = append(key, sorted...)
+		key = append(key, 0xff)
+	}
+	return string(key)
+}
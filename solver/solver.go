@@ -0,0 +1,222 @@
+// Package solver computes the maximum score that can be achieved in a game of Hanabi with perfect information.
+//
+// As a GameState knows the order of the stack and every hand, the solver can search all possible continuations of a game.
+// This makes it possible to determine if a game that was lost could have been won at all.
+package solver
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tkw1536/hanabi/model"
+)
+
+// DefaultMaxNodes is the default number of positions a Solver visits before giving up.
+const DefaultMaxNodes = 1000000
+
+// Solver searches for an optimal sequence of moves in a game with perfect information.
+//
+// The search is a depth-first search with memoization over the order of the stack, the hands, the piles and the tokens.
+// All hints are considered equivalent, as hints only pass information that is already known.
+// Misplays are only considered when discarding is not possible.
+type Solver struct {
+	// MaxNodes is the maximum number of positions visited.
+	// When 0, uses DefaultMaxNodes.
+	MaxNodes int
+}
+
+// Solution is the result of a search.
+type Solution struct {
+	// Score is the best score found
+	Score int
+
+	// Moves is a sequence of moves that achieves Score when applied to the game.
+	// When the search did not reach the end of the game, Moves is nil and Score is the current score.
+	Moves []model.Move
+
+	// Optimal indicates if Score is known to be the maximum achievable score.
+	// When the search visits MaxNodes positions before finishing, Score is only a lower bound.
+	Optimal bool
+
+	// Nodes is the number of positions visited
+	Nodes int
+}
+
+// Solve searches state using a Solver with the default settings.
+func Solve(state *model.GameState) (*Solution, error) {
+	return (&Solver{}).Solve(state)
+}
+
+// Solve searches for the maximum score that can be achieved in state, and a sequence of moves achieving it.
+// The state is not modified.
+//
+// When the game has not been started, returns model.ErrGameNotStarted.
+func (s *Solver) Solve(state *model.GameState) (*Solution, error) {
+	if !state.Started {
+		return nil, model.ErrGameNotStarted
+	}
+
+	maxNodes := s.MaxNodes
+	if maxNodes == 0 {
+		maxNodes = DefaultMaxNodes
+	}
+
+	root := newNode(state)
+	search := &search{
+		maxNodes:  maxNodes,
+		memo:      make(map[string]entry),
+		bestScore: -1,
+	}
+	_, complete := search.visit(root)
+
+	solution := &Solution{
+		Score:   search.bestScore,
+		Optimal: complete,
+		Nodes:   search.nodes,
+	}
+	if search.bestScore < 0 {
+		solution.Score = state.Score()
+		return solution, nil
+	}
+
+	moves, err := toMoves(state, search.bestPath)
+	if err != nil {
+		return nil, err
+	}
+	solution.Moves = moves
+	return solution, nil
+}
+
+// ErrInvalidSolution is returned when the moves found by a Solver can not be applied to the game.
+// This indicates that a game uses rules not supported by the Solver.
+var ErrInvalidSolution = errors.New("Solver: Solution can not be applied to game")
+
+// toMoves converts a path found by a search into moves applied to state.
+func toMoves(state *model.GameState, path []move) ([]model.Move, error) {
+	game := state.Clone()
+
+	var moves []model.Move
+	for _, m := range path {
+		next := model.Move{Kind: m.Kind, Index: m.Index}
+		if m.Kind == model.MoveHint {
+			hint, ok := firstHint(game.LegalMoves())
+			if !ok {
+				return nil, ErrInvalidSolution
+			}
+			next = hint
+		}
+		if err := game.Apply(next); err != nil {
+			return nil, errors.Wrap(ErrInvalidSolution, err.Error())
+		}
+		moves = append(moves, game.Moves[len(game.Moves)-1])
+	}
+	return moves, nil
+}
+
+// firstHint returns the first hint in moves.
+func firstHint(moves []model.Move) (model.Move, bool) {
+	for _, m := range moves {
+		if m.Kind == model.MoveHint {
+			return m, true
+		}
+	}
+	return model.Move{}, false
+}
+
+// move is a move considered by the search.
+// For hints, only the kind is set.
+type move struct {
+	Kind  model.MoveKind
+	Index int
+}
+
+// entry is the result of a completely searched position
+type entry struct {
+	score int
+	best  move
+	final bool // the position is the end of the game
+}
+
+// search holds the state of a single search
+type search struct {
+	maxNodes int
+	nodes    int
+
+	memo map[string]entry
+
+	path      []move
+	bestPath  []move
+	bestScore int
+}
+
+// visit searches the position n and returns the best score that can be achieved from it.
+// complete indicates if the search of n was complete, and the score is exact.
+func (s *search) visit(n *node) (score int, complete bool) {
+	key := n.key()
+	if e, ok := s.memo[key]; ok {
+		s.record(n, e.score)
+		return e.score, true
+	}
+
+	if n.over() {
+		score := n.score()
+		s.record(n, score)
+		s.memo[key] = entry{score: score, final: true}
+		return score, true
+	}
+
+	if s.nodes >= s.maxNodes {
+		return 0, false
+	}
+	s.nodes++
+
+	bound := n.bound()
+
+	best := entry{score: -1}
+	complete = true
+	for _, m := range n.moves() {
+		child := n.apply(m)
+
+		s.path = append(s.path, m)
+		score, ok := s.visit(child)
+		s.path = s.path[:len(s.path)-1]
+
+		if !ok {
+			complete = false
+			continue
+		}
+		if score > best.score {
+			best.score, best.best = score, m
+		}
+		if best.score >= bound {
+			complete = true
+			break
+		}
+	}
+
+	if complete && best.score >= 0 {
+		s.memo[key] = best
+	}
+	return best.score, complete && best.score >= 0
+}
+
+// record records that the position n at the end of the current path achieves score.
+// If it is better than the best score so far, the moves leading to it are stored.
+func (s *search) record(n *node, score int) {
+	if score <= s.bestScore {
+		return
+	}
+
+	path := append([]move(nil), s.path...)
+
+	// follow the best moves of memoized positions to the end of the game
+	for {
+		e := s.memo[n.key()]
+		if e.final || n.over() {
+			break
+		}
+		path = append(path, e.best)
+		n = n.apply(e.best)
+	}
+
+	s.bestScore = score
+	s.bestPath = path
+}
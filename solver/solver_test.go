@@ -0,0 +1,127 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/tkw1536/hanabi/model"
+)
+
+// newGame starts a new game with the provided number of players and seed.
+func newGame(t *testing.T, players int, seed int64) *model.GameState {
+	state := &model.GameState{Mode: model.ModeFiveColor}
+	for i := 0; i < players; i++ {
+		if _, err := state.AddPlayer(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := state.Start(seed); err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+// checkSolution checks that applying the moves of solution to state achieves its score.
+func checkSolution(t *testing.T, state *model.GameState, solution *Solution) {
+	game := state.Clone()
+	for i, move := range solution.Moves {
+		if err := game.Apply(move); err != nil {
+			t.Fatalf("move %d: GameState.Apply() error = %v", i, err)
+		}
+	}
+	if !game.Over() {
+		t.Error("Solution.Moves do not end the game")
+	}
+	if game.Score() != solution.Score {
+		t.Errorf("Solution.Moves achieve score %v, want %v", game.Score(), solution.Score)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	for _, players := range []int{2, 3, 5} {
+		state := newGame(t, players, 1)
+
+		solution, err := Solve(state)
+		if err != nil {
+			t.Fatalf("Solve() error = %v", err)
+		}
+		if solution.Score != 25 || !solution.Optimal {
+			t.Errorf("%d players: Solve() = %v (optimal %v), want 25 (optimal true)", players, solution.Score, solution.Optimal)
+		}
+		checkSolution(t, state, solution)
+
+		if len(state.Moves) != 0 {
+			t.Error("Solve() modified the game")
+		}
+	}
+}
+
+func TestSolve_Lost(t *testing.T) {
+	// find a game where the first player starts out with a five
+	var state *model.GameState
+	five := -1
+	for seed := int64(1); five == -1; seed++ {
+		state = newGame(t, 2, seed)
+		for i, card := range state.Players[0].Hand {
+			if card.Number == model.NumberFive {
+				five = i
+			}
+		}
+	}
+
+	// and discard it
+	for i := 0; i < 2; i++ {
+		moves := state.LegalMoves()
+		if err := state.Apply(moves[len(moves)-1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := state.Apply(model.Move{Kind: model.MoveDiscard, Index: five}); err != nil {
+		t.Fatal(err)
+	}
+
+	solution, err := Solve(state)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if solution.Score != 24 || !solution.Optimal {
+		t.Errorf("Solve() = %v (optimal %v), want 24 (optimal true)", solution.Score, solution.Optimal)
+	}
+	checkSolution(t, state, solution)
+}
+
+func TestSolver_Solve_MaxNodes(t *testing.T) {
+	state := newGame(t, 3, 1)
+
+	solution, err := (&Solver{MaxNodes: 10}).Solve(state)
+	if err != nil {
+		t.Fatalf("Solver.Solve() error = %v", err)
+	}
+	if solution.Optimal || solution.Moves != nil || solution.Score != 0 || solution.Nodes != 10 {
+		t.Errorf("Solver.Solve() = %+v, want incomplete solution", solution)
+	}
+}
+
+func TestSolve_Over(t *testing.T) {
+	state := newGame(t, 2, 1)
+	solution, err := Solve(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, move := range solution.Moves {
+		if err := state.Apply(move); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	solution, err = Solve(state)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if solution.Score != 25 || !solution.Optimal || len(solution.Moves) != 0 {
+		t.Errorf("Solve() = %+v, want finished solution", solution)
+	}
+
+	if _, err := Solve(&model.GameState{Mode: model.ModeFiveColor}); err != model.ErrGameNotStarted {
+		t.Errorf("Solve() error = %v, want %v", err, model.ErrGameNotStarted)
+	}
+}